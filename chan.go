@@ -0,0 +1,68 @@
+package safestack
+
+import "context"
+
+// ToChan - stream items off the top of the stack into the returned channel, waiting whenever the stack is empty.
+// the channel is closed once ctx is done or the stack is closed. an item popped but not yet received when ctx ends
+// goes back on top of the stack, or is evicted if the stack refuses it; one in flight when the stack closes is evicted.
+func (s *SafeStack[T]) ToChan(ctx context.Context) <-chan T {
+	s.mutex.Lock()
+	done := s.doneChan()
+	s.unlock()
+
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for {
+			item, err := s.PopWait(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- item:
+			case <-ctx.Done():
+				s.reclaim(item)
+				return
+			case <-done:
+				s.reclaim(item)
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// reclaim - put back an item that ToChan() popped but never delivered, evicting it if the stack is full or closed;
+// an evicted item counts as pushed back first, so that Stats() still balance.
+func (s *SafeStack[T]) reclaim(item T) {
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.push(item) != nil {
+		s.counts.pushes++
+		s.evict(item)
+	}
+}
+
+// FromChan - push everything received on ch onto the stack, from a goroutine of its own, until ch is closed,
+// ctx is done, or the stack is closed. the mirror of ToChan().
+func (s *SafeStack[T]) FromChan(ctx context.Context, ch <-chan T) {
+	s.mutex.Lock()
+	done := s.doneChan()
+	s.unlock()
+
+	go func() {
+		for {
+			select {
+			case item, ok := <-ch:
+				if !ok {
+					return
+				}
+				s.Push(item)
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,95 @@
+package safestack
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// evictLog - an OnEvict hook that records what it is given
+type evictLog[T any] struct {
+	mu    sync.Mutex
+	items []T
+}
+
+func (l *evictLog[T]) hook(item T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.items = append(l.items, item)
+}
+
+func (l *evictLog[T]) get() []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.items)
+}
+
+// eventually - wait up to a second for cond to hold
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting until %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestToChanEvictsInFlightOnClose(t *testing.T) {
+	var log evictLog[int]
+	s := NewSafeStack([]int{1})
+	s.OnEvict(log.hook)
+	ch := s.ToChan(context.Background())
+	eventually(t, "ToChan pops the item", func() bool { return s.Len() == 0 })
+
+	before := s.Stats()
+	s.Close()
+	if _, ok := <-ch; ok {
+		t.Fatal("received from ToChan after Close")
+	}
+	if got := log.get(); !slices.Equal(got, []int{1}) {
+		t.Fatalf("evicted %v; want [1]", got)
+	}
+	if st := s.Stats(); st.Pushes-before.Pushes != st.Pops-before.Pops+st.Evictions-before.Evictions {
+		t.Fatalf("Stats() do not balance: %+v, then %+v", before, st)
+	}
+}
+
+func TestToChanEvictsRefusedOnCancel(t *testing.T) {
+	var log evictLog[int]
+	s := NewSafeStack([]int{1})
+	s.NewMax(1)
+	s.SetOverflowPolicy(Reject)
+	s.OnEvict(log.hook)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := s.ToChan(ctx)
+	eventually(t, "ToChan pops the item", func() bool { return s.Len() == 0 })
+
+	s.Push(2)
+	cancel()
+	for range ch {
+		t.Fatal("received from ToChan after cancel")
+	}
+	if got := log.get(); !slices.Equal(got, []int{1}) {
+		t.Fatalf("evicted %v; want [1]", got)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{2}) {
+		t.Fatalf("stack holds %v; want [2]", got)
+	}
+}
+
+func TestToChanPushesBackOnCancel(t *testing.T) {
+	s := NewSafeStack([]int{1})
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := s.ToChan(ctx)
+	eventually(t, "ToChan pops the item", func() bool { return s.Len() == 0 })
+
+	cancel()
+	for range ch {
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{1}) {
+		t.Fatalf("stack holds %v; want [1]", got)
+	}
+}
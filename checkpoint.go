@@ -0,0 +1,72 @@
+package safestack
+
+import "fmt"
+
+// CheckpointID - identifies a checkpoint taken by Checkpoint()
+type CheckpointID uint64
+
+// checkpoint - a saved copy of the contents of a stack
+type checkpoint[T any] struct {
+	id    CheckpointID
+	items []T
+}
+
+// Checkpoint - save the current contents so that Rollback() can return to them; checkpoints nest.
+func (s *SafeStack[T]) Checkpoint() CheckpointID {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.lastCheckpoint++
+	items := make([]T, len(s.Items))
+	copy(items, s.Items)
+	s.checkpoints = append(s.checkpoints, checkpoint[T]{id: s.lastCheckpoint, items: items})
+	return s.lastCheckpoint
+}
+
+// Rollback - restore the contents saved by Checkpoint(); id and any checkpoint taken after it are done with.
+// the limits in force now apply to what comes back: beyond Maxsize or the weight cap the deepest items are evicted.
+func (s *SafeStack[T]) Rollback(id CheckpointID) error {
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	i, err := s.findCheckpoint(id)
+	if err != nil {
+		return err
+	}
+	s.Items = s.checkpoints[i].items
+	clear(s.checkpoints[i:])
+	s.checkpoints = s.checkpoints[:i]
+	s.journal.reset(s.Items)
+	s.changed()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
+	if s.maxWeight > 0 {
+		s.evictToBytes(s.maxWeight)
+	}
+	return nil
+}
+
+// Commit - keep the changes made since Checkpoint(); id and any checkpoint taken after it are done with.
+func (s *SafeStack[T]) Commit(id CheckpointID) error {
+	s.mutex.Lock()
+	defer s.unlock()
+	i, err := s.findCheckpoint(id)
+	if err != nil {
+		return err
+	}
+	clear(s.checkpoints[i:])
+	s.checkpoints = s.checkpoints[:i]
+	return nil
+}
+
+// findCheckpoint - return the position of the checkpoint id; the caller holds the write lock
+func (s *SafeStack[T]) findCheckpoint(id CheckpointID) (int, error) {
+	for i, c := range s.checkpoints {
+		if c.id == id {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown checkpoint %d", id)
+}
@@ -0,0 +1,114 @@
+package safestack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCheckpointRollback(t *testing.T) {
+	s := NewSafeStack([]int{1, 2})
+	id := s.Checkpoint()
+	s.Push(3)
+	_, _ = s.Pop()
+	_, _ = s.Pop()
+	if err := s.Rollback(id); err != nil {
+		t.Fatalf("Rollback() = %v", err)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("after Rollback() the stack holds %v; want [1 2]", got)
+	}
+	if err := s.Rollback(id); err == nil {
+		t.Fatal("a second Rollback() to the same checkpoint succeeded")
+	}
+}
+
+func TestCheckpointNested(t *testing.T) {
+	s := NewSafeStack([]int{1})
+	outer := s.Checkpoint()
+	s.Push(2)
+	inner := s.Checkpoint()
+	s.Push(3)
+
+	if err := s.Rollback(inner); err != nil {
+		t.Fatalf("Rollback(inner) = %v", err)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("after Rollback(inner) the stack holds %v; want [1 2]", got)
+	}
+	if err := s.Rollback(outer); err != nil {
+		t.Fatalf("Rollback(outer) = %v", err)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{1}) {
+		t.Fatalf("after Rollback(outer) the stack holds %v; want [1]", got)
+	}
+}
+
+func TestCheckpointCommit(t *testing.T) {
+	s := NewSafeStack([]int{1})
+	outer := s.Checkpoint()
+	s.Push(2)
+	inner := s.Checkpoint()
+	s.Push(3)
+	if err := s.Commit(outer); err != nil {
+		t.Fatalf("Commit(outer) = %v", err)
+	}
+	if err := s.Rollback(inner); err == nil {
+		t.Fatal("Rollback() to a checkpoint taken after a committed one succeeded")
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("after Commit() the stack holds %v; want [1 2 3]", got)
+	}
+}
+
+func TestRollbackHonorsLimits(t *testing.T) {
+	var log evictLog[int]
+	s := NewSafeStack([]int{1, 2, 3, 4})
+	id := s.Checkpoint()
+	s.NewMax(2)
+	s.OnEvict(log.hook)
+	if err := s.Rollback(id); err != nil {
+		t.Fatalf("Rollback() = %v", err)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{3, 4}) {
+		t.Fatalf("after Rollback() under Maxsize 2 the stack holds %v; want [3 4]", got)
+	}
+	if got := log.get(); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("Rollback() evicted %v; want [1 2]", got)
+	}
+}
+
+func TestRollbackHonorsWeight(t *testing.T) {
+	s := NewSafeStack([]block{5, 5, 5})
+	id := s.Checkpoint()
+	s.SetMaxWeight(10)
+	if err := s.Rollback(id); err != nil {
+		t.Fatalf("Rollback() = %v", err)
+	}
+	if s.Len() != 2 || s.Bytes() != 10 {
+		t.Fatalf("after Rollback() under a budget of 10 the stack holds %d items of weight %d; want 2 of 10", s.Len(), s.Bytes())
+	}
+}
+
+func TestRollbackZeroesCheckpoints(t *testing.T) {
+	s := NewSafeStack([]*int{new(int)})
+	outer := s.Checkpoint()
+	s.Checkpoint()
+	if err := s.Rollback(outer); err != nil {
+		t.Fatalf("Rollback() = %v", err)
+	}
+	for _, c := range s.checkpoints[:cap(s.checkpoints)] {
+		if c.items != nil {
+			t.Fatal("Rollback() left a dropped checkpoint reachable")
+		}
+	}
+}
+
+func TestRollbackClosed(t *testing.T) {
+	s := NewSafeStack([]int{1})
+	id := s.Checkpoint()
+	s.Close()
+	if err := s.Rollback(id); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Rollback() of a closed stack = %v; want ErrClosed", err)
+	}
+}
@@ -0,0 +1,155 @@
+package safestack
+
+import (
+	"fmt"
+	"sync"
+)
+
+// defaultChunk - the # of items per segment of a ChunkedSafeStack unless told otherwise
+const defaultChunk = 1024
+
+// ChunkedSafeStack - a stack stored as a list of fixed-size segments rather than one slice: a push never copies the
+// items already in the stack, and dropping the bottom item to honor the max size frees whole segments as they empty.
+// it suits very large stacks, where regrowing one slice would hold the lock for milliseconds. a type of its own because
+// SafeStack exposes its storage as Items.
+type ChunkedSafeStack[T any] struct {
+	segs    [][]T // all full but the last; segs[0][:head] has already been dropped
+	head    int
+	n       int
+	chunk   int
+	spare   []T // the last segment to empty, kept against a push right at a segment boundary
+	maxsize int
+	mutex   sync.RWMutex
+}
+
+// NewChunkedSafeStack - the factory function; return a *ChunkedSafeStack[T] that stores chunk items per segment
+// (1024 if chunk is 0 or less)
+func NewChunkedSafeStack[T any](chunk int) *ChunkedSafeStack[T] {
+	if chunk <= 0 {
+		chunk = defaultChunk
+	}
+	return &ChunkedSafeStack[T]{chunk: chunk}
+}
+
+// NewMax - set a new max stack size, 0 for none; trim to that size if necessary; drop the deepest items first.
+// NewMax(2) on stack [1, 2, 3] -> [2, 3]
+func (s *ChunkedSafeStack[T]) NewMax(n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.maxsize = n
+	for s.maxsize > 0 && s.n > s.maxsize {
+		s.dropBottom()
+	}
+}
+
+// Push - add an item to the top of the stack; drop the bottom item if that exceeds the max size.
+func (s *ChunkedSafeStack[T]) Push(item T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.segs) == 0 || len(s.segs[len(s.segs)-1]) == s.chunk {
+		seg := s.spare
+		if seg == nil {
+			seg = make([]T, 0, s.chunk)
+		}
+		s.spare = nil
+		s.segs = append(s.segs, seg)
+	}
+	top := len(s.segs) - 1
+	s.segs[top] = append(s.segs[top], item)
+	s.n++
+
+	if s.maxsize > 0 && s.n > s.maxsize {
+		s.dropBottom()
+	}
+}
+
+// dropBottom - remove the deepest item, releasing its segment once that is empty; the caller holds the write lock
+func (s *ChunkedSafeStack[T]) dropBottom() {
+	clear(s.segs[0][s.head : s.head+1])
+	s.head++
+	s.n--
+	if s.head == len(s.segs[0]) {
+		s.segs[0] = nil
+		s.segs = s.segs[1:]
+		s.head = 0
+	}
+}
+
+// Pop - pop the top item from the stack leaving it smaller by one.
+// Pop() from stack [1, 2, 3] -> return 3; and now stack is [1, 2].
+func (s *ChunkedSafeStack[T]) Pop() (T, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var i T
+	if s.n == 0 {
+		return i, fmt.Errorf("empty stack")
+	}
+
+	t := len(s.segs) - 1
+	seg := s.segs[t]
+	i = seg[len(seg)-1]
+	clear(seg[len(seg)-1:])
+	s.segs[t] = seg[:len(seg)-1]
+	s.n--
+
+	if len(s.segs[t]) == 0 || (t == 0 && len(s.segs[t]) == s.head) {
+		if t > 0 {
+			s.spare = s.segs[t][:0]
+		}
+		s.segs[t] = nil
+		s.segs = s.segs[:t]
+		if t == 0 {
+			s.head = 0
+		}
+	}
+	return i, nil
+}
+
+// Peek - look at the top item in the stack; but do not pop it.
+// Peek() from stack [1, 2, 3] -> return 3; and stack is still [1, 2, 3]
+func (s *ChunkedSafeStack[T]) Peek() (T, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var i T
+	if s.n == 0 {
+		return i, fmt.Errorf("empty stack")
+	}
+	seg := s.segs[len(s.segs)-1]
+	return seg[len(seg)-1], nil
+}
+
+// Len - return the # of items in the stack.
+func (s *ChunkedSafeStack[T]) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.n
+}
+
+// PeekAll - return all items in the stack but leave the stack unchanged; last in first out.
+// Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> PeekAll() returns [3, 2, 1]
+func (s *ChunkedSafeStack[T]) PeekAll() []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	all := make([]T, 0, s.n)
+	for i := len(s.segs) - 1; i >= 0; i-- {
+		low := 0
+		if i == 0 {
+			low = s.head
+		}
+		for j := len(s.segs[i]) - 1; j >= low; j-- {
+			all = append(all, s.segs[i][j])
+		}
+	}
+	return all
+}
+
+// Clear - empty the stack.
+func (s *ChunkedSafeStack[T]) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.segs, s.head, s.n, s.spare = nil, 0, 0, nil
+}
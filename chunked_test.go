@@ -0,0 +1,64 @@
+package safestack
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// TestChunkedModel - run random operations on a ChunkedSafeStack with tiny segments against a plain slice.
+// pushes, pops and trims cross segment boundaries in both directions, so the spare segment and head see every case.
+func TestChunkedModel(t *testing.T) {
+	for _, chunk := range []int{1, 2, 3, 8} {
+		s := NewChunkedSafeStack[int](chunk)
+		var model []int // bottom first
+		maxsize := 0
+		rng := rand.New(rand.NewPCG(uint64(chunk), 312))
+
+		for i := range 2000 {
+			var op string
+			switch r := rng.IntN(20); {
+			case r < 10:
+				op = "Push"
+				s.Push(i)
+				model = append(model, i)
+				if maxsize > 0 && len(model) > maxsize {
+					model = model[len(model)-maxsize:]
+				}
+			case r < 17:
+				op = "Pop"
+				got, err := s.Pop()
+				if len(model) == 0 {
+					if err == nil {
+						t.Fatalf("chunk %d, op %d: Pop() of an empty stack succeeded", chunk, i)
+					}
+					break
+				}
+				if want := model[len(model)-1]; err != nil || got != want {
+					t.Fatalf("chunk %d, op %d: Pop() = %d, %v; want %d", chunk, i, got, err, want)
+				}
+				model = model[:len(model)-1]
+			case r < 19:
+				op = "NewMax"
+				maxsize = rng.IntN(3 * chunk)
+				s.NewMax(maxsize)
+				if maxsize > 0 && len(model) > maxsize {
+					model = model[len(model)-maxsize:]
+				}
+			default:
+				op = "Clear"
+				s.Clear()
+				model = nil
+			}
+
+			want := slices.Clone(model)
+			slices.Reverse(want)
+			if got := s.PeekAll(); !slices.Equal(got, want) || s.Len() != len(model) {
+				t.Fatalf("chunk %d, op %d (%s): PeekAll() = %v with Len() %d; want %v", chunk, i, op, got, s.Len(), want)
+			}
+			if top, err := s.Peek(); len(model) > 0 && (err != nil || top != model[len(model)-1]) {
+				t.Fatalf("chunk %d, op %d (%s): Peek() = %d, %v; want %d", chunk, i, op, top, err, model[len(model)-1])
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package safestack
+
+// Cloner - implemented by items that know how to deep-copy themselves
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// cloneItem - return item.Clone() if the item is a Cloner[T], else a plain copy of it
+func cloneItem[T any](item T) T {
+	if c, ok := any(item).(Cloner[T]); ok {
+		return c.Clone()
+	}
+	return item
+}
+
+// Clone - return an independent copy of the stack: fresh storage, with items that implement Cloner[T] deep-copied.
+// the copy keeps Maxsize and the overflow, empty, growth, size, weight, shrink, text codec and copy-on-write settings,
+// but no hooks and no journal.
+func (s *SafeStack[T]) Clone() *SafeStack[T] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	c := NewSafeStack(make([]T, len(s.Items)))
+	for i, item := range s.Items {
+		c.Items[i] = cloneItem(item)
+	}
+	c.Maxsize = s.Maxsize
+	c.overflow = s.overflow
+	c.onEmpty = s.onEmpty
+	c.grow = s.grow
+	c.sizeof = s.sizeof
+	c.maxWeight = s.maxWeight
+	c.shrink = s.shrink
+	c.text = s.text
+	c.cow, c.stale = s.cow, s.cow
+	c.publish()
+	return c
+}
+
+// PopCopy - TryPop() but deep-copy the item if it implements Cloner[T], so that it shares nothing with the shallow
+// copies handed out before it was popped, by Snapshot() say.
+func (s *SafeStack[T]) PopCopy() (T, bool) {
+	i, ok := s.TryPop()
+	if ok {
+		i = cloneItem(i)
+	}
+	return i, ok
+}
+
+// PeekAllCopy - PeekAll() with every item that implements Cloner[T] deep-copied.
+func (s *SafeStack[T]) PeekAllCopy() []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	all := make([]T, len(s.Items))
+	for i := range all {
+		all[i] = cloneItem(s.Items[len(s.Items)-1-i])
+	}
+	return all
+}
+
+// SnapshotCopy - Snapshot() with every item that implements Cloner[T] deep-copied.
+func (s *SafeStack[T]) SnapshotCopy() []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	c := make([]T, len(s.Items))
+	for i, item := range s.Items {
+		c[i] = cloneItem(item)
+	}
+	return c
+}
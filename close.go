@@ -0,0 +1,39 @@
+package safestack
+
+import "errors"
+
+// ErrClosed - returned by operations on a stack after Close()
+var ErrClosed = errors.New("stack closed")
+
+// Close - shut the stack down: evict its contents (firing the OnEvict hook), wake anything blocked on it,
+// and stop the goroutines it owns, such as those of ToChan() and StartSampler(), closing the channels of Subscribe().
+// afterwards Pop(), Peek(), and their kin return ErrClosed and pushes are dropped. closing a closed stack returns ErrClosed.
+func (s *SafeStack[T]) Close() error {
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.closed {
+		return ErrClosed
+	}
+
+	s.closed = true
+	s.evict(s.Items...)
+	s.empty()
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+	close(s.done)
+	for sub := range s.subs {
+		sub.stop()
+	}
+	s.subs = nil
+	return s.journal.close()
+}
+
+// doneChan - return a channel that Close() closes; goroutines owned by the stack exit on it.
+// the caller holds the write lock.
+func (s *SafeStack[T]) doneChan() <-chan struct{} {
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+	return s.done
+}
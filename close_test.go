@@ -0,0 +1,100 @@
+package safestack
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// closedWithin - report whether ch is closed, draining anything still in it, within a second
+func closedWithin[T any](ch <-chan T) bool {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return true
+			}
+		case <-timeout:
+			return false
+		}
+	}
+}
+
+func TestCloseEvicts(t *testing.T) {
+	var log evictLog[int]
+	s := NewSafeStack([]int{1, 2, 3})
+	s.OnEvict(log.hook)
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if got := log.get(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("Close() evicted %v; want [1 2 3]", got)
+	}
+	if err := s.Close(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("second Close() = %v; want ErrClosed", err)
+	}
+}
+
+func TestCloseChannels(t *testing.T) {
+	s := NewSafeStack([]int{})
+	sub, _ := s.Subscribe()
+	out := s.ToChan(context.Background())
+	s.Close()
+	if !closedWithin(sub) {
+		t.Fatal("the Subscribe() channel stayed open after Close")
+	}
+	if !closedWithin(out) {
+		t.Fatal("the ToChan() channel stayed open after Close")
+	}
+}
+
+func TestCloseStopsGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+	s := NewSafeStack([]int{})
+	s.ToChan(context.Background())
+	s.FromChan(context.Background(), make(chan int))
+	s.StartSampler(time.Millisecond, 4)
+	if runtime.NumGoroutine() <= before {
+		t.Fatal("the stack started no goroutines")
+	}
+	s.Close()
+	eventually(t, "the stack's goroutines exit", func() bool { return runtime.NumGoroutine() <= before })
+}
+
+func TestAfterClose(t *testing.T) {
+	s := NewSafeStack([]int{1})
+	s.Close()
+	ctx := context.Background()
+
+	for name, err := range map[string]error{
+		"Pop":      second(s.Pop()),
+		"Peek":     second(s.Peek()),
+		"PushErr":  s.PushErr(2),
+		"PopWait":  second(s.PopWait(ctx)),
+		"PushWait": s.PushWait(ctx, 2),
+		"Dup":      s.Dup(),
+	} {
+		if !errors.Is(err, ErrClosed) {
+			t.Errorf("%s after Close = %v; want ErrClosed", name, err)
+		}
+	}
+	if _, ok := s.TryPop(); ok {
+		t.Error("TryPop after Close succeeded")
+	}
+	s.Push(3)
+	if s.Len() != 0 {
+		t.Fatalf("a Push after Close was kept: Len() = %d", s.Len())
+	}
+	if err := s.WaitUntilNonEmpty(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("WaitUntilNonEmpty after Close = %v; want ErrClosed", err)
+	}
+}
+
+// second - the error of a (value, error) pair
+func second[T any](_ T, err error) error {
+	return err
+}
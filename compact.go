@@ -0,0 +1,35 @@
+package safestack
+
+// minShrinkCap - below this capacity auto-shrink leaves the storage alone; reallocating tiny slices buys nothing
+const minShrinkCap = 64
+
+// Compact - move the items into storage of exactly their size, releasing spare capacity and whatever the backing array
+// still holds past the items, e.g. after a Push() over Maxsize dropped the bottom item.
+func (s *SafeStack[T]) Compact() {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.compact()
+}
+
+// compact - the body of Compact(); the caller holds the write lock
+func (s *SafeStack[T]) compact() {
+	if cap(s.Items) == len(s.Items) {
+		return
+	}
+	items := make([]T, len(s.Items))
+	copy(items, s.Items)
+	s.Items = items
+}
+
+// SetAutoShrink - with on, compact the stack whenever its capacity has grown to more than four times its length,
+// so that memory taken by a burst of pushes is given back once the stack drains.
+func (s *SafeStack[T]) SetAutoShrink(on bool) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.shrink = on
+}
+
+// shrinkDue - report whether auto-shrink should compact the stack now; the caller holds the write lock
+func (s *SafeStack[T]) shrinkDue() bool {
+	return s.shrink && cap(s.Items) > minShrinkCap && cap(s.Items) > 4*len(s.Items)
+}
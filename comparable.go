@@ -0,0 +1,164 @@
+package safestack
+
+import "slices"
+
+// CompareAndSwapAll - replace the contents of the stack with a copy of next, but only if the current contents equal expected.
+// items are compared element-wise in push order; returns whether the swap happened. Maxsize applies to next as to RePopulate().
+// CompareAndSwapAll(s, [1, 2], [7, 8, 9]) on stack [1, 2] -> true; and now stack is [7, 8, 9]
+func CompareAndSwapAll[T comparable](s *SafeStack[T], expected, next []T) bool {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	if s.closed || len(s.Items) != len(expected) {
+		return false
+	}
+	for i := range s.Items {
+		if s.Items[i] != expected[i] {
+			return false
+		}
+	}
+
+	s.Items = make([]T, len(next))
+	copy(s.Items, next)
+	s.journal.reset(s.Items)
+	s.changed()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
+	return true
+}
+
+// MergeUnique - push onto dst, bottom first, each item of src that dst does not already hold; return the # pushed.
+// dst's Maxsize applies as usual, and an item evicted along the way counts as missing from dst again.
+// src is left unchanged.
+// MergeUnique(dst, src) with dst [1, 2] and src [2, 3, 3, 4] -> return 2; and now dst is [1, 2, 3, 4]
+func MergeUnique[T comparable](dst, src *SafeStack[T]) int {
+	unlock := lockPair(dst, src, false)
+	defer unlock()
+
+	if dst == src {
+		return 0
+	}
+
+	present := holding(dst.Items)
+	added := 0
+	for _, item := range src.Items {
+		if present[item] {
+			continue
+		}
+		n := len(dst.Items)
+		if dst.push(item) != nil {
+			continue
+		}
+		added++
+		if len(dst.Items) == n+1 {
+			present[item] = true
+		} else {
+			// the push evicted something, which may be held no longer
+			present = holding(dst.Items)
+		}
+	}
+	return added
+}
+
+// holding - the set of items
+func holding[T comparable](items []T) map[T]bool {
+	present := make(map[T]bool, len(items))
+	for _, item := range items {
+		present[item] = true
+	}
+	return present
+}
+
+// Contains - report whether item is in the stack; runs under the read lock without copying the stack.
+// for frequent membership checks on a large stack consider an IndexedSafeStack instead.
+func Contains[T comparable](s *SafeStack[T], item T) bool {
+	return Index(s, item) >= 0
+}
+
+// Index - return the depth of the topmost copy of item, 0 being the top; or -1 if the stack does not hold it.
+// Index(s, 2) on stack [2, 1, 2, 3] -> return 1
+func Index[T comparable](s *SafeStack[T], item T) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for i := len(s.Items) - 1; i >= 0; i-- {
+		if s.Items[i] == item {
+			return len(s.Items) - 1 - i
+		}
+	}
+	return -1
+}
+
+// Remove - remove the topmost copy of item from wherever it sits in the stack; report whether there was one.
+// Remove(s, 2) on stack [2, 1, 2, 3] -> return true; and now stack is [2, 1, 3]
+func Remove[T comparable](s *SafeStack[T], item T) bool {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	i := len(s.Items) - 1
+	for i >= 0 && s.Items[i] != item {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	s.Items = slices.Delete(s.Items, i, i+1)
+	s.journal.reset(s.Items)
+	s.changed()
+	return true
+}
+
+// RemoveAll - remove every copy of item from the stack and return how many went; the rest keep their order.
+// RemoveAll(s, 2) on stack [2, 1, 2, 3] -> return 2; and now stack is [1, 3]
+func RemoveAll[T comparable](s *SafeStack[T], item T) int {
+	s.mutex.Lock()
+	defer s.unlock()
+	return len(s.extract(func(t T) bool { return t == item }))
+}
+
+// PushUnique - push item unless the stack already holds it; check and push are one step. returns whether item was pushed.
+// PushUnique(s, 2) onto stack [1, 2] -> false; and stack is still [1, 2]
+func PushUnique[T comparable](s *SafeStack[T], item T) bool {
+	return s.PushUniqueFunc(item, func(a, b T) bool { return a == b })
+}
+
+// Dedupe - remove duplicate items in one step, keeping the topmost copy of each if keepTop, else the deepest; return the # removed.
+// Dedupe(s, true) on stack [1, 2, 1, 3] -> return 1; and now stack is [2, 1, 3]
+func Dedupe[T comparable](s *SafeStack[T], keepTop bool) int {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	seen := make(map[T]bool, len(s.Items))
+	return s.dedupe(keepTop, func(item T) bool {
+		if seen[item] {
+			return true
+		}
+		seen[item] = true
+		return false
+	})
+}
+
+// CompareAndPop - pop the top item, but only if it equals expected; returns whether the pop happened.
+// CompareAndPop(s, 3) on stack [1, 2, 3] -> true; and now stack is [1, 2]
+func CompareAndPop[T comparable](s *SafeStack[T], expected T) bool {
+	_, ok := s.PopIf(func(top T) bool { return top == expected })
+	return ok
+}
+
+// CompareAndPush - push item, but only if the current top equals expectedTop; returns whether the push happened.
+// an empty stack has no top, so nothing is pushed onto it.
+// CompareAndPush(s, 3, 4) on stack [1, 2, 3] -> true; and now stack is [1, 2, 3, 4]
+func CompareAndPush[T comparable](s *SafeStack[T], expectedTop, item T) bool {
+	s.mutex.Lock()
+	defer s.unlock()
+	if len(s.Items) == 0 || s.Items[len(s.Items)-1] != expectedTop {
+		return false
+	}
+	return s.push(item) == nil
+}
+
+// Equal - report whether the two stacks hold equal items in the same order; see EqualFunc().
+// Equal(a, b) with a [1, 2] and b [1, 2] -> true
+func Equal[T comparable](a, b *SafeStack[T]) bool {
+	return a.EqualFunc(b, func(x, y T) bool { return x == y })
+}
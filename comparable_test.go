@@ -0,0 +1,109 @@
+package safestack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompareAndSwapAll(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		expected []int
+		swapped  bool
+	}{
+		{"match", []int{1, 2}, true},
+		{"mismatch", []int{1, 3}, false},
+		{"shorter", []int{1}, false},
+		{"longer", []int{1, 2, 3}, false},
+		{"empty", []int{}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSafeStack([]int{1, 2})
+			next := []int{7, 8, 9}
+			if got := CompareAndSwapAll(s, tc.expected, next); got != tc.swapped {
+				t.Fatalf("CompareAndSwapAll(%v) = %v; want %v", tc.expected, got, tc.swapped)
+			}
+			want := []int{1, 2}
+			if tc.swapped {
+				want = slices.Clone(next)
+			}
+			if got := s.Snapshot(); !slices.Equal(got, want) {
+				t.Fatalf("stack holds %v; want %v", got, want)
+			}
+			next[0] = -1
+			if got := s.Snapshot(); !slices.Equal(got, want) {
+				t.Fatalf("stack shares next: it holds %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCompareAndSwapAllMaxsize(t *testing.T) {
+	var log evictLog[int]
+	s := NewSafeStack([]int{1, 2})
+	s.NewMax(2)
+	s.OnEvict(log.hook)
+	if !CompareAndSwapAll(s, []int{1, 2}, []int{7, 8, 9, 10}) {
+		t.Fatal("CompareAndSwapAll did not swap")
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{9, 10}) {
+		t.Fatalf("stack holds %v; want [9 10]", got)
+	}
+	if got := log.get(); !slices.Equal(got, []int{7, 8}) {
+		t.Fatalf("evicted %v; want [7 8]", got)
+	}
+}
+
+func TestCompareAndSwapAllClosed(t *testing.T) {
+	s := NewSafeStack([]int{})
+	s.Close()
+	if CompareAndSwapAll(s, []int{}, []int{1}) {
+		t.Fatal("CompareAndSwapAll swapped a closed stack")
+	}
+}
+
+func TestMergeUnique(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		dst, src []int
+		added    int
+		want     []int
+	}{
+		{"full overlap", []int{1, 2, 3}, []int{3, 1, 2}, 0, []int{1, 2, 3}},
+		{"partial overlap", []int{1, 2}, []int{2, 3, 3, 4}, 2, []int{1, 2, 3, 4}},
+		{"disjoint", []int{1, 2}, []int{3, 4}, 2, []int{1, 2, 3, 4}},
+		{"empty src", []int{1}, []int{}, 0, []int{1}},
+		{"empty dst", []int{}, []int{5, 5, 6}, 2, []int{5, 6}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dst, src := NewSafeStack(tc.dst), NewSafeStack(slices.Clone(tc.src))
+			if added := MergeUnique(dst, src); added != tc.added {
+				t.Fatalf("MergeUnique() = %d; want %d", added, tc.added)
+			}
+			if got := dst.Snapshot(); !slices.Equal(got, tc.want) {
+				t.Fatalf("dst holds %v; want %v", got, tc.want)
+			}
+			if got := src.Snapshot(); !slices.Equal(got, tc.src) {
+				t.Fatalf("src changed to %v", got)
+			}
+		})
+	}
+}
+
+func TestMergeUniqueEvicted(t *testing.T) {
+	dst := NewSafeStack([]int{1, 2})
+	dst.NewMax(2)
+	if added := MergeUnique(dst, NewSafeStack([]int{3, 1})); added != 2 {
+		t.Fatalf("MergeUnique() = %d; want 2", added)
+	}
+	if got := dst.Snapshot(); !slices.Equal(got, []int{3, 1}) {
+		t.Fatalf("dst holds %v; want [3 1]: 1 was evicted by 3 and so is missing again", got)
+	}
+}
+
+func TestMergeUniqueSelf(t *testing.T) {
+	s := NewSafeStack([]int{1, 2})
+	if added := MergeUnique(s, s); added != 0 || s.Len() != 2 {
+		t.Fatalf("MergeUnique(s, s) = %d leaving %d items; want 0 leaving 2", added, s.Len())
+	}
+}
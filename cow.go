@@ -0,0 +1,37 @@
+package safestack
+
+// SetCopyOnWrite - turn copy-on-write mode on or off. in this mode every write publishes an immutable copy of the items,
+// and Len(), PeekAll(), and iteration read that copy without taking the lock at all, so readers never wait for writers
+// nor writers for readers. the price is a copy of the whole stack per write: worth it when reads vastly outnumber writes.
+// code that writes to Items directly, bypassing the methods, also bypasses the published copy.
+func (s *SafeStack[T]) SetCopyOnWrite(on bool) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.cow = on
+	if on {
+		s.stale = true
+	} else {
+		s.published.Store(nil)
+	}
+}
+
+// publish - store a fresh copy of the items for lock-free readers if a write has happened since the last one;
+// the caller holds the write lock
+func (s *SafeStack[T]) publish() {
+	if !s.cow || !s.stale {
+		return
+	}
+	items := make([]T, len(s.Items))
+	copy(items, s.Items)
+	s.published.Store(&items)
+	s.stale = false
+}
+
+// cowItems - return the published copy of the items, bottom first, and true; or nil, false outside copy-on-write mode.
+// the copy is shared by all readers and must not be modified.
+func (s *SafeStack[T]) cowItems() ([]T, bool) {
+	if p := s.published.Load(); p != nil {
+		return *p, true
+	}
+	return nil, false
+}
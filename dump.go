@@ -0,0 +1,75 @@
+package safestack
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// stringTop - the # of items String() shows
+const stringTop = 10
+
+// DumpOptions - what Dump() writes
+type DumpOptions[T any] struct {
+	Top    int            // the # of items to write, from the top down; 0 or less for all of them
+	Format func(T) string // formats an item; fmt.Sprint() if nil
+}
+
+// String - describe the stack on one line for debugging, with up to 10 items from the top down:
+// "SafeStack len=3 max=5 [3 2 1]"
+func (s *SafeStack[T]) String() string {
+	n, maxsize, top := s.top(stringTop)
+	var b strings.Builder
+	fmt.Fprintf(&b, "SafeStack len=%d max=%d [", n, maxsize)
+	for i, item := range top {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprint(&b, item)
+	}
+	if n > len(top) {
+		b.WriteString(" ...")
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Dump - write the length, Maxsize, and the top opts.Top items of the stack to w, one item per line with its depth.
+// the items are copied under the read lock and formatted after it is released.
+func (s *SafeStack[T]) Dump(w io.Writer, opts DumpOptions[T]) error {
+	n, maxsize, top := s.top(opts.Top)
+	format := opts.Format
+	if format == nil {
+		format = func(item T) string { return fmt.Sprint(item) }
+	}
+
+	if _, err := fmt.Fprintf(w, "len: %d\nmaxsize: %d\n", n, maxsize); err != nil {
+		return err
+	}
+	for i, item := range top {
+		if _, err := fmt.Fprintf(w, "%4d: %s\n", i, format(item)); err != nil {
+			return err
+		}
+	}
+	if n > len(top) {
+		if _, err := fmt.Fprintf(w, "... and %d more\n", n-len(top)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// top - return the length, the Maxsize, and a copy of up to k items from the top down (all of them if k <= 0)
+func (s *SafeStack[T]) top(k int) (int, int, []T) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	n := len(s.Items)
+	if k <= 0 || k > n {
+		k = n
+	}
+	top := make([]T, k)
+	for i := range top {
+		top[i] = s.Items[n-1-i]
+	}
+	return n, s.Maxsize, top
+}
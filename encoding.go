@@ -0,0 +1,86 @@
+package safestack
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// stackDoc - the serialized form of a SafeStack: its items in push order and its Maxsize
+type stackDoc[T any] struct {
+	Items   []T `json:"items"`
+	Maxsize int `json:"maxsize"`
+}
+
+// doc - return the serialized form of the stack
+func (s *SafeStack[T]) doc() stackDoc[T] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	c := make([]T, len(s.Items))
+	copy(c, s.Items)
+	return stackDoc[T]{Items: c, Maxsize: s.Maxsize}
+}
+
+// load - replace the contents and Maxsize of the stack with those of d; d.Items becomes the storage.
+// ErrClosed, leaving the stack as it is, once Close() has been called.
+func (s *SafeStack[T]) load(d stackDoc[T]) error {
+	if d.Items == nil {
+		d.Items = []T{}
+	}
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	s.Items = d.Items
+	s.Maxsize = d.Maxsize
+	s.journal.reset(s.Items)
+	s.journal.op(opMax, s.Maxsize)
+	s.changed()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
+	return nil
+}
+
+// MarshalJSON - implement json.Marshaler: {"items": [1, 2, 3], "maxsize": 5}, items in push order.
+func (s *SafeStack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.doc())
+}
+
+// UnmarshalJSON - implement json.Unmarshaler; the inverse of MarshalJSON().
+func (s *SafeStack[T]) UnmarshalJSON(data []byte) error {
+	var d stackDoc[T]
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	return s.load(d)
+}
+
+// MarshalBinary - implement encoding.BinaryMarshaler via gob; the items and Maxsize as for MarshalJSON().
+func (s *SafeStack[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.doc()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary - implement encoding.BinaryUnmarshaler; the inverse of MarshalBinary().
+func (s *SafeStack[T]) UnmarshalBinary(data []byte) error {
+	var d stackDoc[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&d); err != nil {
+		return err
+	}
+	return s.load(d)
+}
+
+// GobEncode - implement gob.GobEncoder, so that stacks can travel over net/rpc; the same as MarshalBinary().
+func (s *SafeStack[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode - implement gob.GobDecoder; the inverse of GobEncode().
+func (s *SafeStack[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
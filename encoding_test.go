@@ -0,0 +1,101 @@
+package safestack
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3})
+	s.NewMax(5)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `{"items":[1,2,3],"maxsize":5}` {
+		t.Fatalf("Marshal() = %s", data)
+	}
+
+	r := NewSafeStack([]int{9})
+	if err = json.Unmarshal(data, r); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got := r.Snapshot(); !slices.Equal(got, []int{1, 2, 3}) || r.Maxsize != 5 {
+		t.Fatalf("Unmarshal() gave %v with Maxsize %d; want [1 2 3] and 5", got, r.Maxsize)
+	}
+
+	// a stack within a larger document
+	var doc struct {
+		Name  string
+		Stack *SafeStack[string]
+	}
+	if err = json.Unmarshal([]byte(`{"Name":"n","Stack":{"items":["a","b","c"],"maxsize":2}}`), &doc); err != nil {
+		t.Fatalf("Unmarshal of a document: %v", err)
+	}
+	if got := doc.Stack.Snapshot(); !slices.Equal(got, []string{"b", "c"}) {
+		t.Fatalf("Unmarshal() past Maxsize gave %v; want [b c], trimmed from the bottom", got)
+	}
+}
+
+func TestJSONUnmarshalClosed(t *testing.T) {
+	s := NewSafeStack([]int{})
+	_ = s.Close()
+	if err := json.Unmarshal([]byte(`{"items":[1],"maxsize":0}`), s); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Unmarshal into a closed stack: %v; want ErrClosed", err)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("Unmarshal into a closed stack left Len() %d; want 0", s.Len())
+	}
+	if err := json.Unmarshal([]byte(`{"items":`), NewSafeStack([]int{})); err == nil {
+		t.Fatal("Unmarshal of truncated JSON succeeded")
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	type entry struct {
+		ID   int
+		Tags []string
+	}
+	s := NewSafeStack([]entry{{ID: 1, Tags: []string{"x"}}, {ID: 2}})
+	s.NewMax(4)
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	r := NewSafeStack([]entry{})
+	if err = r.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if got := r.Snapshot(); len(got) != 2 || got[0].ID != 1 || !slices.Equal(got[0].Tags, []string{"x"}) || got[1].ID != 2 {
+		t.Fatalf("UnmarshalBinary() gave %v; want the items pushed", got)
+	}
+	if r.Maxsize != 4 {
+		t.Fatalf("UnmarshalBinary() gave Maxsize %d; want 4", r.Maxsize)
+	}
+	if err = r.UnmarshalBinary(data[:len(data)/2]); err == nil {
+		t.Fatal("UnmarshalBinary of truncated data succeeded")
+	}
+
+	// through encoding/gob, as net/rpc sends it
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(NewSafeStack([]int{1, 2, 3})); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	ints := NewSafeStack([]int{})
+	if err = gob.NewDecoder(&buf).Decode(ints); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got := ints.Snapshot(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("Decode() gave %v; want [1 2 3]", got)
+	}
+
+	data, _ = ints.GobEncode()
+	_ = ints.Close()
+	if err = ints.GobDecode(data); !errors.Is(err, ErrClosed) {
+		t.Fatalf("GobDecode into a closed stack: %v; want ErrClosed", err)
+	}
+}
@@ -0,0 +1,140 @@
+package safestack
+
+// EvictWhere - evict every item that satisfies pred, wherever it sits; return the evicted items in push order.
+// the OnEvict hook fires for each of them.
+// EvictWhere(isEven) on stack [1, 2, 3, 4] -> return [2, 4]; and now stack is [1, 3]
+func (s *SafeStack[T]) EvictWhere(pred func(T) bool) []T {
+	s.mutex.Lock()
+	defer s.unlock()
+	evicted := s.extract(pred)
+	s.evict(evicted...)
+	return evicted
+}
+
+// DrainMatching - remove every item that satisfies pred and return them in push order; the rest keep their order.
+// DrainMatching(isEven) on stack [1, 2, 3, 4] -> return [2, 4]; and now stack is [1, 3]
+func (s *SafeStack[T]) DrainMatching(pred func(T) bool) []T {
+	s.mutex.Lock()
+	defer s.unlock()
+	return s.extract(pred)
+}
+
+// RemoveWhere - remove every item that satisfies pred, wherever it sits, and return how many went; the rest keep their order.
+// RemoveWhere(isEven) on stack [1, 2, 3, 4] -> return 2; and now stack is [1, 3]
+func (s *SafeStack[T]) RemoveWhere(pred func(T) bool) int {
+	s.mutex.Lock()
+	defer s.unlock()
+	return len(s.extract(pred))
+}
+
+// ExtractN - remove up to n items that satisfy pred and return them in the order found, the rest keeping their order.
+// with lifo the search runs down from the top, otherwise up from the bottom.
+// ExtractN(isEven, 2, true) on stack [1, 2, 3, 4, 6] -> return [6, 4]; and now stack is [1, 2, 3]
+// ExtractN(isEven, 2, false) on stack [1, 2, 3, 4, 6] -> return [2, 4]; and now stack is [1, 3, 6]
+func (s *SafeStack[T]) ExtractN(pred func(T) bool, n int, lifo bool) []T {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	taken := make(map[int]bool)
+	var found []T
+	for k := 0; k < len(s.Items) && len(found) < n; k++ {
+		i := k
+		if lifo {
+			i = len(s.Items) - 1 - k
+		}
+		if pred(s.Items[i]) {
+			taken[i] = true
+			found = append(found, s.Items[i])
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	kept := s.Items[:0]
+	for i, item := range s.Items {
+		if !taken[i] {
+			kept = append(kept, item)
+		}
+	}
+	clear(s.Items[len(kept):])
+	s.Items = kept
+	s.journal.reset(s.Items)
+	s.changed()
+	return found
+}
+
+// DedupeFunc - remove every item that eq reports equal to another one, keeping one of each; return the # removed.
+// with keepTop the topmost copy survives, otherwise the deepest one; survivors keep their order.
+// DedupeFunc(eq, true) on stack [1, 2, 1, 3] -> return 1; and now stack is [2, 1, 3]
+// DedupeFunc(eq, false) on stack [1, 2, 1, 3] -> return 1; and now stack is [1, 2, 3]
+func (s *SafeStack[T]) DedupeFunc(eq func(a, b T) bool, keepTop bool) int {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	var kept []T
+	return s.dedupe(keepTop, func(item T) bool {
+		for _, k := range kept {
+			if eq(k, item) {
+				return true
+			}
+		}
+		kept = append(kept, item)
+		return false
+	})
+}
+
+// dedupe - drop every item for which seen reports true, visiting from the top down if keepTop, else from the bottom up;
+// return the # dropped. the caller holds the write lock.
+func (s *SafeStack[T]) dedupe(keepTop bool, seen func(T) bool) int {
+	drop := make([]bool, len(s.Items))
+	n := 0
+	for k := range s.Items {
+		i := k
+		if keepTop {
+			i = len(s.Items) - 1 - k
+		}
+		if seen(s.Items[i]) {
+			drop[i] = true
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+
+	kept := s.Items[:0]
+	for i, item := range s.Items {
+		if !drop[i] {
+			kept = append(kept, item)
+		}
+	}
+	clear(s.Items[len(kept):])
+	s.Items = kept
+	s.journal.reset(s.Items)
+	s.changed()
+	return n
+}
+
+// extract - remove the items that satisfy pred preserving the order of the rest; return the removed items in push order.
+// vacated slots are zeroed so that the backing array does not pin them. the caller holds the write lock.
+func (s *SafeStack[T]) extract(pred func(T) bool) []T {
+	var matched []T
+	kept := s.Items[:0]
+	for _, item := range s.Items {
+		if pred(item) {
+			matched = append(matched, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	clear(s.Items[len(kept):])
+	s.Items = kept
+	s.journal.reset(s.Items)
+	s.changed()
+	return matched
+}
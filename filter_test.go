@@ -0,0 +1,85 @@
+package safestack
+
+import (
+	"slices"
+	"testing"
+)
+
+func isEven(i int) bool { return i%2 == 0 }
+
+func TestEvictWhere(t *testing.T) {
+	var log evictLog[int]
+	s := NewSafeStack([]int{1, 2, 3, 4, 5, 6})
+	s.OnEvict(log.hook)
+
+	evicted := s.EvictWhere(isEven)
+	if !slices.Equal(evicted, []int{2, 4, 6}) {
+		t.Fatalf("EvictWhere(isEven) = %v; want [2 4 6]", evicted)
+	}
+	if got := log.get(); !slices.Equal(got, []int{2, 4, 6}) {
+		t.Fatalf("OnEvict saw %v; want [2 4 6]", got)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{1, 3, 5}) {
+		t.Fatalf("stack holds %v; want [1 3 5]", got)
+	}
+}
+
+func TestDrainMatching(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		items         []int
+		drained, kept []int
+	}{
+		{"all match", []int{2, 4, 6}, []int{2, 4, 6}, []int{}},
+		{"none match", []int{1, 3, 5}, []int{}, []int{1, 3, 5}},
+		{"interleaved", []int{1, 2, 3, 4, 5, 6}, []int{2, 4, 6}, []int{1, 3, 5}},
+		{"empty", []int{}, []int{}, []int{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var log evictLog[int]
+			s := NewSafeStack(slices.Clone(tc.items))
+			s.OnEvict(log.hook)
+			if got := s.DrainMatching(isEven); !slices.Equal(got, tc.drained) {
+				t.Fatalf("DrainMatching(isEven) = %v; want %v", got, tc.drained)
+			}
+			if got := s.Snapshot(); !slices.Equal(got, tc.kept) {
+				t.Fatalf("stack holds %v; want %v", got, tc.kept)
+			}
+			if got := log.get(); len(got) != 0 {
+				t.Fatalf("DrainMatching fired OnEvict with %v", got)
+			}
+		})
+	}
+}
+
+func TestExtractN(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		n           int
+		lifo        bool
+		found, kept []int
+	}{
+		{"fewer than the matches, from the top", 2, true, []int{6, 4}, []int{1, 2, 3}},
+		{"fewer than the matches, from the bottom", 2, false, []int{2, 4}, []int{1, 3, 6}},
+		{"more than the matches, from the top", 5, true, []int{6, 4, 2}, []int{1, 3}},
+		{"more than the matches, from the bottom", 5, false, []int{2, 4, 6}, []int{1, 3}},
+		{"none wanted", 0, true, nil, []int{1, 2, 3, 4, 6}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSafeStack([]int{1, 2, 3, 4, 6})
+			if got := s.ExtractN(isEven, tc.n, tc.lifo); !slices.Equal(got, tc.found) {
+				t.Fatalf("ExtractN(isEven, %d, %v) = %v; want %v", tc.n, tc.lifo, got, tc.found)
+			}
+			if got := s.Snapshot(); !slices.Equal(got, tc.kept) {
+				t.Fatalf("stack holds %v; want %v", got, tc.kept)
+			}
+		})
+	}
+}
+
+func TestExtractNNoMatch(t *testing.T) {
+	s := NewSafeStack([]int{1, 3})
+	if got := s.ExtractN(isEven, 3, true); got != nil || s.Len() != 2 {
+		t.Fatalf("ExtractN with no match = %v leaving %d; want nil leaving 2", got, s.Len())
+	}
+}
@@ -0,0 +1,80 @@
+package safestack
+
+import "fmt"
+
+// the Forth words for shuffling the top of a stack; each runs as one step under the write lock.
+// in the examples the top of the stack is on the right.
+
+// Dup - push a second copy of the top item: ( a -- a a )
+// Dup() on stack [1, 2] -> stack [1, 2, 2]
+func (s *SafeStack[T]) Dup() error {
+	s.mutex.Lock()
+	defer s.unlock()
+	if err := s.need("Dup", 1); err != nil {
+		return err
+	}
+	return s.push(s.Items[len(s.Items)-1])
+}
+
+// SwapTop - exchange the top two items: ( a b -- b a )
+// SwapTop() on stack [1, 2, 3] -> stack [1, 3, 2]
+func (s *SafeStack[T]) SwapTop() error {
+	s.mutex.Lock()
+	defer s.unlock()
+	if err := s.need("SwapTop", 2); err != nil {
+		return err
+	}
+	n := len(s.Items)
+	s.Items[n-2], s.Items[n-1] = s.Items[n-1], s.Items[n-2]
+	s.rewroteTop(2)
+	return nil
+}
+
+// Rot - bring the third item to the top: ( a b c -- b c a )
+// Rot() on stack [1, 2, 3] -> stack [2, 3, 1]
+func (s *SafeStack[T]) Rot() error {
+	s.mutex.Lock()
+	defer s.unlock()
+	if err := s.need("Rot", 3); err != nil {
+		return err
+	}
+	n := len(s.Items)
+	s.Items[n-3], s.Items[n-2], s.Items[n-1] = s.Items[n-2], s.Items[n-1], s.Items[n-3]
+	s.rewroteTop(3)
+	return nil
+}
+
+// Over - push a copy of the second item: ( a b -- a b a )
+// Over() on stack [1, 2] -> stack [1, 2, 1]
+func (s *SafeStack[T]) Over() error {
+	s.mutex.Lock()
+	defer s.unlock()
+	if err := s.need("Over", 2); err != nil {
+		return err
+	}
+	return s.push(s.Items[len(s.Items)-2])
+}
+
+// need - fail unless the stack holds at least n items; the caller holds the write lock
+func (s *SafeStack[T]) need(op string, n int) error {
+	switch {
+	case s.closed:
+		return ErrClosed
+	case len(s.Items) == 0:
+		return fmt.Errorf("empty stack")
+	case len(s.Items) < n:
+		return fmt.Errorf("%s needs %d items; stack holds %d", op, n, len(s.Items))
+	}
+	return nil
+}
+
+// rewroteTop - journal and announce that the top n items were rearranged in place; the caller holds the write lock
+func (s *SafeStack[T]) rewroteTop(n int) {
+	for range n {
+		s.journal.op(opPop, 0)
+	}
+	for _, item := range s.Items[len(s.Items)-n:] {
+		s.journal.push(item)
+	}
+	s.changed()
+}
@@ -0,0 +1,80 @@
+package safestack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestForthWords(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		word func(s *SafeStack[int]) error
+		need int
+		want []int // from [1, 2, 3], top on the right
+	}{
+		{"Dup", (*SafeStack[int]).Dup, 1, []int{1, 2, 3, 3}},
+		{"SwapTop", (*SafeStack[int]).SwapTop, 2, []int{1, 3, 2}},
+		{"Rot", (*SafeStack[int]).Rot, 3, []int{2, 3, 1}},
+		{"Over", (*SafeStack[int]).Over, 2, []int{1, 2, 3, 2}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSafeStack([]int{1, 2, 3})
+			if err := tc.word(s); err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if got := s.Snapshot(); !slices.Equal(got, tc.want) {
+				t.Fatalf("%s on [1 2 3] left %v; want %v", tc.name, got, tc.want)
+			}
+
+			short := NewSafeStack([]int{1, 2, 3}[:tc.need-1])
+			if err := tc.word(short); err == nil {
+				t.Fatalf("%s on %d items succeeded; it needs %d", tc.name, tc.need-1, tc.need)
+			}
+			if got := short.Snapshot(); len(got) != tc.need-1 {
+				t.Fatalf("a failed %s changed the stack to %v", tc.name, got)
+			}
+
+			if err := tc.word(NewSafeStack([]int{})); err == nil || err.Error() != "empty stack" {
+				t.Fatalf("%s on an empty stack: %v; want empty stack", tc.name, err)
+			}
+			closed := NewSafeStack([]int{1, 2, 3})
+			_ = closed.Close()
+			if err := tc.word(closed); !errors.Is(err, ErrClosed) {
+				t.Fatalf("%s on a closed stack: %v; want ErrClosed", tc.name, err)
+			}
+		})
+	}
+}
+
+func TestForthMaxsize(t *testing.T) {
+	// Dup and Over push, so Maxsize and the overflow policy apply to them
+	s := NewSafeStack([]int{1, 2, 3})
+	s.NewMax(3)
+	if err := s.Dup(); err != nil {
+		t.Fatalf("Dup: %v", err)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{2, 3, 3}) {
+		t.Fatalf("Dup() at Maxsize left %v; want [2 3 3]", got)
+	}
+	s.SetOverflowPolicy(ReturnError)
+	if err := s.Over(); !errors.Is(err, ErrFull) {
+		t.Fatalf("Over() at Maxsize under ReturnError: %v; want ErrFull", err)
+	}
+}
+
+func TestForthTracer(t *testing.T) {
+	s := NewSafeStack([]int{})
+	var log traceLog
+	s.SetTracer(&log)
+	s.PushMany([]int{1, 2, 3})
+	before := s.Version()
+	_ = s.Rot()
+	if s.Version() == before {
+		t.Error("Version() unchanged by Rot()")
+	}
+	_ = s.Over()
+	if !slices.Equal(log.pushed, []int{1, 2, 3, 3}) {
+		t.Errorf("Tracer.Pushed() saw %v; want [1 2 3 3]: Rot does not push, Over does", log.pushed)
+	}
+}
@@ -0,0 +1,58 @@
+package safestack
+
+import (
+	"fmt"
+	"slices"
+)
+
+// FrozenStack - an immutable snapshot of a SafeStack; see Freeze().
+// nothing can change it, so it needs no mutex and is safe to share between goroutines.
+type FrozenStack[T any] struct {
+	items []T
+}
+
+// Freeze - return an immutable copy of the stack.
+func (s *SafeStack[T]) Freeze() FrozenStack[T] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return FrozenStack[T]{items: slices.Clone(s.Items)}
+}
+
+// Len - return the # of items in the frozen stack.
+func (f FrozenStack[T]) Len() int {
+	return len(f.items)
+}
+
+// Peek - look at the top item in the frozen stack.
+// Peek() from frozen stack [1, 2, 3] -> return 3
+func (f FrozenStack[T]) Peek() (T, error) {
+	if len(f.items) == 0 {
+		var i T
+		return i, fmt.Errorf("empty stack")
+	}
+	return f.items[len(f.items)-1], nil
+}
+
+// At - look at the item i places below the top of the frozen stack.
+// At(1) from frozen stack [1, 2, 3] -> return 2
+func (f FrozenStack[T]) At(i int) (T, error) {
+	var item T
+	if i < 0 || i >= len(f.items) {
+		return item, fmt.Errorf("index %d out of range for stack of %d", i, len(f.items))
+	}
+	return f.items[len(f.items)-1-i], nil
+}
+
+// PeekAll - return all items in the frozen stack; last in first out.
+// PeekAll() from frozen stack [1, 2, 3] -> return [3, 2, 1]
+func (f FrozenStack[T]) PeekAll() []T {
+	all := make([]T, len(f.items))
+	copy(all, f.items)
+	slices.Reverse(all)
+	return all
+}
+
+// ContainsFunc - report whether any item in the frozen stack satisfies pred.
+func (f FrozenStack[T]) ContainsFunc(pred func(T) bool) bool {
+	return slices.ContainsFunc(f.items, pred)
+}
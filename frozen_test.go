@@ -0,0 +1,59 @@
+package safestack
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestFreezeIndependent(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3})
+	f := s.Freeze()
+
+	s.Push(4)
+	_ = s.SetAt(1, 9)
+	s.Reverse()
+	if f.Len() != 3 {
+		t.Fatalf("frozen Len() = %d; want 3", f.Len())
+	}
+	if top, err := f.Peek(); err != nil || top != 3 {
+		t.Fatalf("frozen Peek() = %d, %v; want 3", top, err)
+	}
+	if got := f.PeekAll(); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Fatalf("frozen PeekAll() = %v; want [3 2 1]", got)
+	}
+	if i, err := f.At(2); err != nil || i != 1 {
+		t.Fatalf("frozen At(2) = %d, %v; want 1", i, err)
+	}
+	if _, err := f.At(3); err == nil {
+		t.Fatal("frozen At(3) of 3 items succeeded")
+	}
+	if _, err := NewSafeStack([]int{}).Freeze().Peek(); err == nil || err.Error() != "empty stack" {
+		t.Fatalf("frozen Peek() of an empty stack: %v; want empty stack", err)
+	}
+
+	all := f.PeekAll()
+	all[0] = -1
+	if top, _ := f.Peek(); top != 3 {
+		t.Fatal("writing to PeekAll()'s result reached the frozen stack")
+	}
+	s.Clear()
+	if !f.ContainsFunc(func(i int) bool { return i == 2 }) {
+		t.Fatal("frozen stack lost an item when the source was cleared")
+	}
+}
+
+func TestFrozenReadOnly(t *testing.T) {
+	readers := []string{"At", "ContainsFunc", "Len", "Peek", "PeekAll"}
+	typ := reflect.TypeFor[FrozenStack[int]]()
+	var methods []string
+	for i := range typ.NumMethod() {
+		methods = append(methods, typ.Method(i).Name)
+	}
+	if !slices.Equal(methods, readers) {
+		t.Fatalf("FrozenStack has methods %v; want only the readers %v", methods, readers)
+	}
+	if n := reflect.TypeFor[*FrozenStack[int]]().NumMethod(); n != len(readers) {
+		t.Fatalf("*FrozenStack has %d methods; want only the %d readers", n, len(readers))
+	}
+}
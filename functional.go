@@ -0,0 +1,108 @@
+package safestack
+
+import "slices"
+
+// Apply - replace every item in the stack with f(item), in place and in order.
+// Apply(double) on stack [1, 2, 3] -> stack [2, 4, 6]
+func (s *SafeStack[T]) Apply(f func(T) T) {
+	s.mutex.Lock()
+	defer s.unlock()
+	for i := range s.Items {
+		s.Items[i] = f(s.Items[i])
+	}
+	s.journal.reset(s.Items)
+	s.changed()
+}
+
+// Transform - the same as Apply(); replace every item with fn(item) under the write lock.
+// Transform(bumpDeadline) updates every queued job at once without draining and repopulating the stack.
+func (s *SafeStack[T]) Transform(fn func(T) T) {
+	s.Apply(fn)
+}
+
+// Sort - sort the stack in place so that the bottom item is the least and the top the greatest by less; equal items keep their order.
+// Sort(less) on stack [3, 1, 2] -> stack [1, 2, 3]; and Pop() returns 3
+func (s *SafeStack[T]) Sort(less func(a, b T) bool) {
+	s.mutex.Lock()
+	defer s.unlock()
+	slices.SortStableFunc(s.Items, compareBy(less))
+	s.journal.reset(s.Items)
+	s.changed()
+}
+
+// IsSorted - report whether the stack is in the order that Sort(less) would put it in.
+func (s *SafeStack[T]) IsSorted(less func(a, b T) bool) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return slices.IsSortedFunc(s.Items, compareBy(less))
+}
+
+// compareBy - turn a less func into the three-way comparison that package slices wants
+func compareBy[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+}
+
+// Fold - fold f over the items of the stack, bottom to top, under the read lock; return the result.
+// the stack is left unchanged and no other goroutine can modify it half-way through.
+// Fold(s, 0, sum) on stack [1, 2, 3] -> return 6; and stack is still [1, 2, 3]
+func Fold[T, A any](s *SafeStack[T], init A, f func(A, T) A) A {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	acc := init
+	for _, item := range s.Items {
+		acc = f(acc, item)
+	}
+	return acc
+}
+
+// FoldAndClear - fold f over the items of the stack, bottom to top, and empty it in one step; return the result.
+// FoldAndClear(s, 0, sum) on stack [1, 2, 3] -> return 6; and now stack is []
+func FoldAndClear[T, A any](s *SafeStack[T], init A, f func(A, T) A) A {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	acc := init
+	for _, item := range s.Items {
+		acc = f(acc, item)
+	}
+
+	s.empty()
+	return acc
+}
+
+// Do - run fn on the items, bottom first, with the write lock held; what fn returns becomes the new contents,
+// trimmed to Maxsize. this makes compound operations atomic: pop, inspect, and conditionally push two, say.
+// fn may modify and return the slice it is given, but must not keep it, nor call methods of the stack.
+func (s *SafeStack[T]) Do(fn func(items []T) []T) {
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.closed {
+		return
+	}
+	s.Items = fn(s.Items)
+	if s.Items == nil {
+		s.Items = []T{}
+	}
+	s.journal.reset(s.Items)
+	s.changed()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
+}
+
+// View - run fn on the items, bottom first, with the read lock held.
+// fn must not modify or keep the slice, nor call methods of the stack that write to it.
+func (s *SafeStack[T]) View(fn func(items []T)) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	fn(s.Items)
+}
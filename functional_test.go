@@ -0,0 +1,120 @@
+package safestack
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3})
+	s.Apply(func(i int) int { return i * 10 })
+	if got := s.Snapshot(); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Fatalf("Apply(x10) left %v; want [10 20 30]", got)
+	}
+	s.Transform(func(i int) int { return i + 1 })
+	if got := s.Snapshot(); !slices.Equal(got, []int{11, 21, 31}) {
+		t.Fatalf("Transform(+1) left %v; want [11 21 31]", got)
+	}
+}
+
+// TestApplyReaders - a reader must see every item transformed the same number of times, never half of an Apply();
+// run it under -race
+func TestApplyReaders(t *testing.T) {
+	s := NewSafeStack(make([]int, 100))
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				all := s.PeekAll()
+				for _, i := range all {
+					if i != all[0] {
+						t.Errorf("a reader saw a half-applied stack: %d next to %d", i, all[0])
+						return
+					}
+				}
+			}
+		}()
+	}
+	for range 200 {
+		s.Apply(func(i int) int { return i + 1 })
+	}
+	close(stop)
+	wg.Wait()
+	if top, _ := s.Peek(); top != 200 {
+		t.Fatalf("top is %d after 200 Apply(+1); want 200", top)
+	}
+}
+
+func TestFoldAndClear(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3})
+	sum := func(acc, i int) int { return acc + i }
+	if got := FoldAndClear(s, 0, sum); got != 6 {
+		t.Fatalf("FoldAndClear(sum) = %d; want 6", got)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("FoldAndClear left %d items", s.Len())
+	}
+	if got := FoldAndClear(s, 10, sum); got != 10 {
+		t.Fatalf("FoldAndClear(sum) of an empty stack = %d; want init, 10", got)
+	}
+
+	s.PushMany([]int{1, 2, 3})
+	order := FoldAndClear(s, []int{}, func(acc []int, i int) []int { return append(acc, i) })
+	if !slices.Equal(order, []int{1, 2, 3}) {
+		t.Fatalf("FoldAndClear folded in the order %v; want bottom to top, [1 2 3]", order)
+	}
+}
+
+// TestFoldAndClearConcurrent - flushes racing with pushes must count every item exactly once
+func TestFoldAndClearConcurrent(t *testing.T) {
+	const pushers, perPusher = 4, 5000
+	s := NewSafeStack([]int{})
+	sum := func(acc, i int) int { return acc + i }
+
+	var pushing, flushing sync.WaitGroup
+	stop := make(chan struct{})
+	totals := make([]int, 3)
+	for f := range totals {
+		flushing.Add(1)
+		go func() {
+			defer flushing.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					totals[f] += FoldAndClear(s, 0, sum)
+				}
+			}
+		}()
+	}
+	for range pushers {
+		pushing.Add(1)
+		go func() {
+			defer pushing.Done()
+			for range perPusher {
+				s.Push(1)
+			}
+		}()
+	}
+	pushing.Wait()
+	close(stop)
+	flushing.Wait()
+
+	total := FoldAndClear(s, 0, sum)
+	for _, n := range totals {
+		total += n
+	}
+	if total != pushers*perPusher {
+		t.Fatalf("the flushes counted %d items; want %d", total, pushers*perPusher)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/e-gun/safestack
 
-go 1.22
+go 1.23
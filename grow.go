@@ -0,0 +1,32 @@
+package safestack
+
+import "math"
+
+// autoGrow - the settings of SetAutoGrow(); the zero value never grows
+type autoGrow struct {
+	factor float64
+	limit  int
+}
+
+// apply - raise *maxsize by the growth factor, capped at the limit, so that it can hold need items if possible;
+// report whether it changed
+func (g autoGrow) apply(maxsize *int, need int) bool {
+	if g.factor <= 1 || *maxsize >= g.limit {
+		return false
+	}
+	grown := *maxsize
+	for grown < need && grown < g.limit {
+		grown = max(int(math.Ceil(float64(grown)*g.factor)), grown+1)
+	}
+	*maxsize = min(grown, g.limit)
+	return true
+}
+
+// SetAutoGrow - when a push would overflow a full stack, multiply Maxsize by factor instead, as far as limit.
+// past limit the stack evicts as usual. a factor of 1 or less switches growth off.
+// SetAutoGrow(2, 8) on a full stack with Maxsize 3 -> pushes grow Maxsize to 6, then to 8, then evict
+func (s *SafeStack[T]) SetAutoGrow(factor float64, limit int) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.grow = autoGrow{factor: factor, limit: limit}
+}
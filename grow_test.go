@@ -0,0 +1,59 @@
+package safestack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAutoGrow(t *testing.T) {
+	var log evictLog[int]
+	s := NewSafeStack([]int{})
+	s.NewMax(3)
+	s.SetAutoGrow(2, 8)
+	s.OnEvict(log.hook)
+
+	for i := 1; i <= 8; i++ {
+		s.Push(i)
+	}
+	if s.Maxsize != 8 || s.Len() != 8 {
+		t.Fatalf("after 8 pushes Maxsize = %d and Len() = %d; want both 8", s.Maxsize, s.Len())
+	}
+	if got := log.get(); len(got) != 0 {
+		t.Fatalf("evicted %v while there was room to grow", got)
+	}
+
+	s.Push(9)
+	s.Push(10)
+	if s.Maxsize != 8 || s.Len() != 8 {
+		t.Fatalf("past the limit Maxsize = %d and Len() = %d; want both 8", s.Maxsize, s.Len())
+	}
+	if got := log.get(); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("past the limit evicted %v; want [1 2]", got)
+	}
+}
+
+func TestAutoGrowSteps(t *testing.T) {
+	s := NewSafeStack([]int{})
+	s.NewMax(3)
+	s.SetAutoGrow(2, 8)
+	var steps []int
+	for i := range 8 {
+		s.Push(i)
+		if len(steps) == 0 || steps[len(steps)-1] != s.Maxsize {
+			steps = append(steps, s.Maxsize)
+		}
+	}
+	if !slices.Equal(steps, []int{3, 6, 8}) {
+		t.Fatalf("Maxsize grew through %v; want [3 6 8]", steps)
+	}
+}
+
+func TestAutoGrowOff(t *testing.T) {
+	s := NewSafeStack([]int{})
+	s.NewMax(2)
+	s.SetAutoGrow(1, 8)
+	s.PushMany([]int{1, 2, 3})
+	if s.Maxsize != 2 || s.Len() != 2 {
+		t.Fatalf("a factor of 1 grew Maxsize to %d", s.Maxsize)
+	}
+}
@@ -0,0 +1,74 @@
+package safestack
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Handler - return an http.Handler for inspecting and operating the stack remotely; items travel as JSON.
+//
+//	GET  /len    {"len": 3}
+//	GET  /peek   the top item; 404 if the stack is empty
+//	POST /push   push the item in the request body, via PushErr(); 409 if it is refused
+//	POST /pop    pop and return the top item; 404 if the stack is empty
+//	POST /drain  pop and return all items, top first
+//
+// the paths are relative: mount it with http.StripPrefix() to put it anywhere,
+// e.g. mux.Handle("/admin/jobs/", http.StripPrefix("/admin/jobs", s.Handler())).
+// it does no authentication of its own.
+func (s *SafeStack[T]) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /len", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]int{"len": s.Len()})
+	})
+	mux.HandleFunc("GET /peek", func(w http.ResponseWriter, r *http.Request) {
+		item, ok := s.TryPeek()
+		if !ok {
+			http.Error(w, "empty stack", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, item)
+	})
+	mux.HandleFunc("POST /push", func(w http.ResponseWriter, r *http.Request) {
+		var item T
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := s.PushErr(item); err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /pop", func(w http.ResponseWriter, r *http.Request) {
+		item, ok := s.TryPop()
+		if !ok {
+			http.Error(w, "empty stack", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, item)
+	})
+	mux.HandleFunc("POST /drain", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, s.DrainLIFO())
+	})
+	return mux
+}
+
+// httpStatus - the status code that reports err
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrFull):
+		return http.StatusConflict
+	case errors.Is(err, ErrClosed):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON - write v as the JSON body of the response
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,120 @@
+package safestack
+
+// OnEvict - register a func to be called with each item that the stack evicts; OnEvict(nil) removes it.
+// that covers items dropped to honor Maxsize, by the overflow policy, by Trim(), NewMax(), and RePopulate(),
+// by the Evict methods, and by Close(). the hook runs after the lock is released, so it may safely call back into the stack.
+func (s *SafeStack[T]) OnEvict(f func(item T)) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.onEvict = f
+}
+
+// lifecycleHooks - the OnPush, OnPop, and OnClear hooks of a stack
+type lifecycleHooks[T any] struct {
+	push  func(T)
+	pop   func(T)
+	clear func()
+}
+
+// wants - report whether a hook is registered for events of kind
+func (h lifecycleHooks[T]) wants(kind byte) bool {
+	switch kind {
+	case opPush:
+		return h.push != nil
+	case opPop:
+		return h.pop != nil
+	case opClear:
+		return h.clear != nil
+	}
+	return false
+}
+
+// set - report whether any of the hooks is registered
+func (h lifecycleHooks[T]) set() bool {
+	return h.push != nil || h.pop != nil || h.clear != nil
+}
+
+// OnPush - register a func to be called with each item pushed onto the stack; OnPush(nil) removes it.
+// like all hooks it runs after the lock is released. a backlog monitor might compare Len() against a threshold here.
+func (s *SafeStack[T]) OnPush(f func(item T)) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.hooks.push = f
+}
+
+// OnPop - register a func to be called with each item popped off the stack, drains included; OnPop(nil) removes it.
+func (s *SafeStack[T]) OnPop(f func(item T)) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.hooks.pop = f
+}
+
+// OnClear - register a func to be called whenever the stack is emptied in one go: by Clear(), a drain, or Close().
+func (s *SafeStack[T]) OnClear(f func()) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.hooks.clear = f
+}
+
+// evict - note items that the stack has just discarded so that unlock() can hand them to the OnEvict hook;
+// the caller holds the write lock
+func (s *SafeStack[T]) evict(items ...T) {
+	s.counts.evictions += uint64(len(items))
+	if s.weighed() {
+		sizeof := s.weigher()
+		for _, item := range items {
+			s.heft -= sizeof(item)
+		}
+	}
+	if s.onEvict != nil {
+		s.evicted = append(s.evicted, items...)
+	}
+}
+
+// unlock - release the write lock, then fire the hooks for whatever happened while it was held.
+// a write-ahead log that is due for compaction is compacted first, while the stack is between operations,
+// then auto-shrink gets its chance, and in copy-on-write mode the new contents are published.
+func (s *SafeStack[T]) unlock() {
+	s.release().fire()
+}
+
+// release - the body of unlock() sans the hooks: release the write lock and return what the hooks are owed, so that
+// a caller holding more than one stack can let go of all of them before any hook runs and might reach for one.
+func (s *SafeStack[T]) release() pending[T] {
+	if s.journal.compactDue() {
+		s.journal.compact(s.Items, s.Maxsize)
+	}
+	if s.shrinkDue() {
+		s.compact()
+	}
+	s.publish()
+	p := pending[T]{evicted: s.evicted, onEvict: s.onEvict, traces: s.traces, tracer: s.tracer, hooks: s.hooks}
+	s.evicted, s.traces = nil, nil
+	s.mutex.Unlock()
+	return p
+}
+
+// pending - the evictions and events of a critical section, with the hooks that were registered at its end
+type pending[T any] struct {
+	evicted []T
+	onEvict func(T)
+	traces  []traceEvent[T]
+	tracer  Tracer[T]
+	hooks   lifecycleHooks[T]
+}
+
+// fire - hand the evictions and events to the hooks; no lock may be held
+func (p pending[T]) fire() {
+	fire(p.onEvict, p.evicted)
+	deliver(p.tracer, p.hooks, p.traces)
+}
+
+// fire - call a hook with each item in turn; a nil hook is a no-op
+func fire[T any](hook func(T), items []T) {
+	if hook == nil {
+		return
+	}
+	for _, item := range items {
+		hook(item)
+	}
+}
@@ -0,0 +1,161 @@
+package safestack
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// IndexedSafeStack - a stack of comparable items that keeps a count of each item so that Contains() costs O(1).
+// in distinct mode every item appears at most once and Maxsize limits the # of distinct items.
+type IndexedSafeStack[T comparable] struct {
+	items    []T
+	counts   map[T]int
+	mutex    sync.RWMutex
+	maxsize  int
+	distinct bool
+}
+
+// NewIndexedSafeStack - the factory function; return a *IndexedSafeStack[T] holding a copy of items
+func NewIndexedSafeStack[T comparable](items []T) *IndexedSafeStack[T] {
+	s := &IndexedSafeStack[T]{
+		items:  make([]T, 0, len(items)),
+		counts: make(map[T]int, len(items)),
+	}
+	for _, item := range items {
+		s.push(item)
+	}
+	return s
+}
+
+// NewMax - set a new max stack size; trim to that size if necessary; drop the deepest items first.
+// NewMax(2) on stack [1, 2, 3] -> [2, 3]
+func (s *IndexedSafeStack[T]) NewMax(n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.maxsize = n
+	s.trim()
+}
+
+// SetDistinct - turn distinct mode on or off; turning it on drops all but the topmost copy of each item.
+// SetDistinct(true) on stack [1, 2, 1, 3] -> [2, 1, 3]
+func (s *IndexedSafeStack[T]) SetDistinct(on bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.distinct = on
+	if !on {
+		return
+	}
+
+	seen := make(map[T]bool, len(s.counts))
+	kept := make([]T, 0, len(s.counts))
+	for i := len(s.items) - 1; i >= 0; i-- {
+		if !seen[s.items[i]] {
+			seen[s.items[i]] = true
+			kept = append(kept, s.items[i])
+		}
+	}
+	slices.Reverse(kept)
+
+	s.items = kept
+	for item := range s.counts {
+		s.counts[item] = 1
+	}
+	s.trim()
+}
+
+// Push - add an item to the top of the stack; drop an item from the bottom if necessary.
+// in distinct mode a duplicate is moved to the top instead: Push(1) onto [1, 2] -> stack [2, 1]
+func (s *IndexedSafeStack[T]) Push(item T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.push(item)
+	s.trim()
+}
+
+// push - the body of Push() sans trimming; the caller holds the write lock
+func (s *IndexedSafeStack[T]) push(item T) {
+	if s.distinct && s.counts[item] > 0 {
+		i := slices.Index(s.items, item)
+		s.items = slices.Delete(s.items, i, i+1)
+		s.counts[item]--
+	}
+	s.items = append(s.items, item)
+	s.counts[item]++
+}
+
+// trim - drop the deepest items until the stack honors maxsize; the caller holds the write lock
+func (s *IndexedSafeStack[T]) trim() {
+	if s.maxsize <= 0 || len(s.items) <= s.maxsize {
+		return
+	}
+	n := len(s.items) - s.maxsize
+	for _, item := range s.items[:n] {
+		s.forget(item)
+	}
+	s.items = slices.Clone(s.items[n:])
+}
+
+// forget - decrement the count for an item on its way out; the caller holds the write lock
+func (s *IndexedSafeStack[T]) forget(item T) {
+	if s.counts[item] <= 1 {
+		delete(s.counts, item)
+	} else {
+		s.counts[item]--
+	}
+}
+
+// Pop - pop the top item from the stack leaving it smaller by one.
+// Pop() from stack [1, 2, 3] -> return 3; and now stack is [1, 2].
+func (s *IndexedSafeStack[T]) Pop() (T, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var i T
+	if len(s.items) == 0 {
+		return i, fmt.Errorf("empty stack")
+	}
+
+	i = s.items[len(s.items)-1]
+	clear(s.items[len(s.items)-1:])
+	s.items = s.items[:len(s.items)-1]
+	s.forget(i)
+	return i, nil
+}
+
+// Peek - look at the top item in the stack; but do not pop it.
+// Peek() from stack [1, 2, 3] -> return 3; and stack is still [1, 2, 3]
+func (s *IndexedSafeStack[T]) Peek() (T, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var i T
+	if len(s.items) == 0 {
+		return i, fmt.Errorf("empty stack")
+	}
+	return s.items[len(s.items)-1], nil
+}
+
+// Len - return the # of items in the stack.
+func (s *IndexedSafeStack[T]) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.items)
+}
+
+// Contains - report whether item is in the stack.
+func (s *IndexedSafeStack[T]) Contains(item T) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.counts[item] > 0
+}
+
+// PeekAll - return all items in the stack but leave the stack unchanged; last in first out.
+// Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> PeekAll() returns [3, 2, 1]
+func (s *IndexedSafeStack[T]) PeekAll() []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	all := slices.Clone(s.items)
+	slices.Reverse(all)
+	return all
+}
@@ -0,0 +1,59 @@
+package safestack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIndexedDistinctDuplicates(t *testing.T) {
+	s := NewIndexedSafeStack([]int{})
+	s.SetDistinct(true)
+	s.NewMax(3)
+	for _, i := range []int{1, 2, 1, 1, 2} {
+		s.Push(i)
+	}
+	if s.Len() != 2 {
+		t.Fatalf("duplicate pushes grew the stack to %d items; want 2", s.Len())
+	}
+	if got := s.PeekAll(); !slices.Equal(got, []int{2, 1}) {
+		t.Fatalf("PeekAll() = %v; want [2 1], the latest push on top", got)
+	}
+}
+
+func TestIndexedDistinctLimit(t *testing.T) {
+	s := NewIndexedSafeStack([]int{})
+	s.SetDistinct(true)
+	s.NewMax(3)
+	for _, i := range []int{1, 2, 3, 1, 4} {
+		s.Push(i)
+	}
+	if got := s.PeekAll(); !slices.Equal(got, []int{4, 1, 3}) {
+		t.Fatalf("PeekAll() = %v; want [4 1 3]: 2, the oldest distinct item, evicted", got)
+	}
+	if s.Contains(2) || !s.Contains(1) {
+		t.Fatal("Contains() disagrees with the contents")
+	}
+}
+
+func TestIndexedSetDistinct(t *testing.T) {
+	s := NewIndexedSafeStack([]int{1, 2, 1, 3})
+	s.SetDistinct(true)
+	if got := s.PeekAll(); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Fatalf("PeekAll() = %v; want [3 1 2]", got)
+	}
+	if i, _ := s.Pop(); i != 3 || s.Len() != 2 {
+		t.Fatalf("Pop() = %d leaving %d; want 3 leaving 2", i, s.Len())
+	}
+}
+
+func TestIndexedCounts(t *testing.T) {
+	s := NewIndexedSafeStack([]int{1, 1})
+	_, _ = s.Pop()
+	if !s.Contains(1) {
+		t.Fatal("Contains(1) is false with a copy of 1 left")
+	}
+	_, _ = s.Pop()
+	if s.Contains(1) {
+		t.Fatal("Contains(1) is true on an empty stack")
+	}
+}
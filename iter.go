@@ -0,0 +1,104 @@
+package safestack
+
+import (
+	"iter"
+	"slices"
+)
+
+// All - range over the stack from the top down: for item := range s.All() {...}
+// the loop runs on a snapshot taken when it starts, so its body may safely push to or pop from the stack.
+func (s *SafeStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		items := s.frozen()
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward - range over a snapshot of the stack from the bottom up, i.e. in push order.
+func (s *SafeStack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.frozen() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// AllIndexed - All() along with each item's depth: 0 for the top, 1 for the item below it, and so on.
+func (s *SafeStack[T]) AllIndexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		items := s.frozen()
+		for i := range items {
+			if !yield(i, items[len(items)-1-i]) {
+				return
+			}
+		}
+	}
+}
+
+// BackwardIndexed - Backward() along with each item's depth, which therefore counts down to 0 at the top.
+func (s *SafeStack[T]) BackwardIndexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		items := s.frozen()
+		for i, item := range items {
+			if !yield(len(items)-1-i, item) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach - call fn on each item from the top down, with its depth, until fn returns false; no copy is made.
+// the read lock is held throughout, so fn must not push to or pop from the stack; range over All() for that.
+// ForEach(fn) on stack [1, 2, 3] -> fn(0, 3), fn(1, 2), fn(2, 1)
+func (s *SafeStack[T]) ForEach(fn func(i int, item T) bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for i := range s.Items {
+		if !fn(i, s.Items[len(s.Items)-1-i]) {
+			return
+		}
+	}
+}
+
+// Collect - return a new stack holding the values of seq, pushed in the order seq yields them; the last one is the top.
+// Collect(slices.Values([]int{1, 2, 3})) -> stack [1, 2, 3]; and Collect(s.Backward()) copies s
+func Collect[T any](seq iter.Seq[T]) *SafeStack[T] {
+	items := slices.Collect(seq)
+	if items == nil {
+		items = []T{}
+	}
+	return NewSafeStack(items)
+}
+
+// AppendSeq - push the values of seq, in the order seq yields them, in one step as with PushMany().
+// seq runs to completion before the stack is locked, so it may itself read from the stack.
+func (s *SafeStack[T]) AppendSeq(seq iter.Seq[T]) {
+	s.PushMany(slices.Collect(seq))
+}
+
+// snapshot - return a copy of the items in push order
+func (s *SafeStack[T]) snapshot() []T {
+	if items, ok := s.cowItems(); ok {
+		return slices.Clone(items)
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	c := make([]T, len(s.Items))
+	copy(c, s.Items)
+	return c
+}
+
+// frozen - return the items in push order in a slice that nobody will write to: the published copy in copy-on-write mode,
+// else a snapshot()
+func (s *SafeStack[T]) frozen() []T {
+	if items, ok := s.cowItems(); ok {
+		return items
+	}
+	return s.snapshot()
+}
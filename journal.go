@@ -0,0 +1,160 @@
+package safestack
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// journal record codes; one record per line: the code, then an optional space and argument
+//
+//	P <base64 item>  push an item
+//	O                pop the top item
+//	C                clear the stack
+//	T <n>            trim the stack down to its top n items
+//	M <n>            set Maxsize to n
+const (
+	opPush  = 'P'
+	opPop   = 'O'
+	opClear = 'C'
+	opTrim  = 'T'
+	opMax   = 'M'
+)
+
+// journal - the optional operation log of a SafeStack; every method expects the stack's write lock to be held.
+// records describe the effect of an operation, not its cause: a Push that evicts is a push plus a trim.
+type journal[T any] struct {
+	w      io.Writer
+	encode func(T) []byte
+	err    error
+	wal    *wal
+}
+
+func (j *journal[T]) active() bool {
+	return j.w != nil && j.err == nil
+}
+
+func (j *journal[T]) write(rec string) {
+	if _, err := io.WriteString(j.w, rec+"\n"); err != nil {
+		j.err = err
+	}
+	if j.wal != nil {
+		j.wal.since++
+	}
+}
+
+func (j *journal[T]) push(item T) {
+	if !j.active() {
+		return
+	}
+	j.write(string(opPush) + " " + base64.RawStdEncoding.EncodeToString(j.encode(item)))
+}
+
+func (j *journal[T]) op(code byte, n int) {
+	if !j.active() {
+		return
+	}
+	switch code {
+	case opTrim, opMax:
+		j.write(string(code) + " " + strconv.Itoa(n))
+	default:
+		j.write(string(code))
+	}
+}
+
+// reset - record the whole content of the stack; used by operations that are not worth journaling piecemeal
+func (j *journal[T]) reset(items []T) {
+	if !j.active() {
+		return
+	}
+	j.op(opClear, 0)
+	for _, item := range items {
+		j.push(item)
+	}
+}
+
+// SetJournal - record every mutating operation to w, encoding pushed items with encode; SetJournal(nil, nil) stops recording.
+// the journal opens with the current contents and Maxsize so that Replay() of it yields an identical stack.
+func (s *SafeStack[T]) SetJournal(w io.Writer, encode func(T) []byte) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.journal.close()
+	s.journal = journal[T]{w: w, encode: encode}
+	s.journal.reset(s.Items)
+	s.journal.op(opMax, s.Maxsize)
+}
+
+// JournalErr - return the write error that stopped the journal, if any.
+func (s *SafeStack[T]) JournalErr() error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.journal.err
+}
+
+// Replay - rebuild a stack from a journal written via SetJournal(); decode is the inverse of the encode func given there.
+// an unterminated final line is the torn tail of an interrupted write and is ignored.
+func Replay[T any](r io.Reader, decode func([]byte) T) (*SafeStack[T], error) {
+	s := NewSafeStack[T]([]T{})
+	maxsize := 0
+
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		rec, err := br.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return s, err
+		}
+		rec = strings.TrimSuffix(rec, "\n")
+		if rec != "" {
+			if e := s.replayRecord(rec, decode, &maxsize); e != nil {
+				return s, fmt.Errorf("journal line %d: %w", line, e)
+			}
+		}
+	}
+
+	s.Maxsize = maxsize
+	return s, nil
+}
+
+// replayRecord - apply one journal record to a stack that is private to Replay(); hence no locking
+func (s *SafeStack[T]) replayRecord(rec string, decode func([]byte) T, maxsize *int) error {
+	code, arg, _ := strings.Cut(rec, " ")
+	if len(code) != 1 {
+		return fmt.Errorf("bad record %q", rec)
+	}
+
+	switch code[0] {
+	case opPush:
+		b, err := base64.RawStdEncoding.DecodeString(arg)
+		if err != nil {
+			return err
+		}
+		s.Items = append(s.Items, decode(b))
+	case opPop:
+		if len(s.Items) == 0 {
+			return fmt.Errorf("pop from empty stack")
+		}
+		s.Items = s.Items[:len(s.Items)-1]
+	case opClear:
+		s.Items = []T{}
+	case opTrim, opMax:
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return err
+		}
+		if code[0] == opMax {
+			*maxsize = n
+		} else if n < len(s.Items) {
+			s.Items = s.Items[len(s.Items)-n:]
+		}
+	default:
+		return fmt.Errorf("unknown record %q", rec)
+	}
+	return nil
+}
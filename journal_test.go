@@ -0,0 +1,105 @@
+package safestack
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func encodeInt(i int) []byte { return []byte(strconv.Itoa(i)) }
+
+func decodeInt(b []byte) int {
+	i, _ := strconv.Atoi(string(b))
+	return i
+}
+
+// TestReplay - record a run of operations and check that replaying the journal yields an identical stack.
+// each case exercises an operation with a journal encoding of its own.
+func TestReplay(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		run  func(s *SafeStack[int])
+	}{
+		{"push pop clear trim", func(s *SafeStack[int]) {
+			s.PushMany([]int{1, 2, 3, 4, 5})
+			_, _ = s.Pop()
+			s.Trim(2)
+			s.Clear()
+			s.Push(6)
+		}},
+		{"push with eviction", func(s *SafeStack[int]) {
+			s.NewMax(3)
+			s.PushMany([]int{1, 2, 3, 4, 5})
+		}},
+		{"drop newest", func(s *SafeStack[int]) {
+			s.NewMax(2)
+			s.SetOverflowPolicy(DropNewest)
+			s.PushMany([]int{1, 2, 3, 4})
+		}},
+		{"reject", func(s *SafeStack[int]) {
+			s.NewMax(2)
+			s.SetOverflowPolicy(Reject)
+			s.PushMany([]int{1, 2, 3})
+		}},
+		{"dup rot move", func(s *SafeStack[int]) {
+			s.PushMany([]int{1, 2, 3})
+			_ = s.Dup()
+			_ = s.Rot()
+			_ = s.SwapTop()
+			_ = s.Over()
+			_ = s.MoveToTop(3)
+		}},
+		{"split", func(s *SafeStack[int]) {
+			s.PushMany([]int{1, 2, 3, 4, 5})
+			s.Split(2)
+			s.Push(6)
+		}},
+		{"split top", func(s *SafeStack[int]) {
+			s.PushMany([]int{1, 2, 3, 4, 5})
+			s.SplitTop(2)
+			s.Push(6)
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			s := NewSafeStack([]int{0})
+			s.SetJournal(&buf, encodeInt)
+			tc.run(s)
+			if err := s.JournalErr(); err != nil {
+				t.Fatalf("JournalErr() = %v", err)
+			}
+
+			r, err := Replay(&buf, decodeInt)
+			if err != nil {
+				t.Fatalf("Replay: %v", err)
+			}
+			if got, want := r.Snapshot(), s.Snapshot(); !slices.Equal(got, want) {
+				t.Fatalf("replayed stack holds %v; want %v", got, want)
+			}
+			if r.Maxsize != s.Maxsize {
+				t.Fatalf("replayed Maxsize %d; want %d", r.Maxsize, s.Maxsize)
+			}
+		})
+	}
+}
+
+func TestReplayTornTail(t *testing.T) {
+	r, err := Replay(strings.NewReader("P MQ\nP Mg\nP Mw"), decodeInt)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if got := r.Snapshot(); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("replayed stack holds %v; want [1 2]", got)
+	}
+}
+
+func TestReplayBadRecord(t *testing.T) {
+	if _, err := Replay(strings.NewReader("P MQ\nO\nO\n"), decodeInt); err == nil {
+		t.Fatal("Replay of a pop from an empty stack succeeded")
+	}
+	if _, err := Replay(strings.NewReader("X\n"), decodeInt); err == nil {
+		t.Fatal("Replay of an unknown record succeeded")
+	}
+}
@@ -0,0 +1,32 @@
+package safestack
+
+import "context"
+
+// Limiter - paces PopLimited(): Wait blocks until the next pop may go ahead or ctx is done.
+// *rate.Limiter from golang.org/x/time/rate satisfies it, as does anything with the same method.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// SetPopLimiter - register the Limiter that paces PopLimited(); SetPopLimiter(nil) removes it.
+func (s *SafeStack[T]) SetPopLimiter(l Limiter) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.limiter = l
+}
+
+// PopLimited - PopWait() at no more than the pace of the pop limiter: wait for the limiter, then for an item.
+// without a limiter it is PopWait() itself. other pops are not throttled.
+func (s *SafeStack[T]) PopLimited(ctx context.Context) (T, error) {
+	s.mutex.RLock()
+	l := s.limiter
+	s.mutex.RUnlock()
+
+	if l != nil {
+		if err := l.Wait(ctx); err != nil {
+			var i T
+			return i, err
+		}
+	}
+	return s.PopWait(ctx)
+}
@@ -0,0 +1,66 @@
+package safestack
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// LockFreeStack - a Treiber stack: a linked list whose head is swapped with compare-and-swap instead of a mutex.
+// it offers only the core of the SafeStack API, but contended pushes and pops never queue behind a lock.
+type LockFreeStack[T any] struct {
+	head atomic.Pointer[lfNode[T]]
+	n    atomic.Int64
+}
+
+// lfNode - a link of a LockFreeStack; immutable once pushed, so the garbage collector rules out ABA
+type lfNode[T any] struct {
+	item T
+	next *lfNode[T]
+}
+
+// NewLockFreeStack - the factory function; return an empty *LockFreeStack[T]
+func NewLockFreeStack[T any]() *LockFreeStack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Push - add an item to the top of the stack.
+func (s *LockFreeStack[T]) Push(item T) {
+	n := &lfNode[T]{item: item}
+	for {
+		n.next = s.head.Load()
+		if s.head.CompareAndSwap(n.next, n) {
+			s.n.Add(1)
+			return
+		}
+	}
+}
+
+// Pop - pop the top item from the stack.
+func (s *LockFreeStack[T]) Pop() (T, error) {
+	for {
+		h := s.head.Load()
+		if h == nil {
+			var i T
+			return i, fmt.Errorf("empty stack")
+		}
+		if s.head.CompareAndSwap(h, h.next) {
+			s.n.Add(-1)
+			return h.item, nil
+		}
+	}
+}
+
+// Peek - look at the top item in the stack; but do not pop it.
+func (s *LockFreeStack[T]) Peek() (T, error) {
+	h := s.head.Load()
+	if h == nil {
+		var i T
+		return i, fmt.Errorf("empty stack")
+	}
+	return h.item, nil
+}
+
+// Len - return the # of items in the stack; approximate while pushes and pops are in flight.
+func (s *LockFreeStack[T]) Len() int {
+	return int(max(s.n.Load(), 0))
+}
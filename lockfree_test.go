@@ -0,0 +1,70 @@
+package safestack
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestLockFreeStack(t *testing.T) {
+	s := NewLockFreeStack[string]()
+	if _, err := s.Pop(); err == nil {
+		t.Fatal("Pop() of an empty stack succeeded")
+	}
+	s.Push("a")
+	s.Push("b")
+	if i, err := s.Peek(); err != nil || i != "b" || s.Len() != 2 {
+		t.Fatalf("Peek() = %q, %v with Len() %d; want b with 2", i, err, s.Len())
+	}
+	for _, want := range []string{"b", "a"} {
+		if i, err := s.Pop(); err != nil || i != want {
+			t.Fatalf("Pop() = %q, %v; want %q", i, err, want)
+		}
+	}
+	if _, err := s.Peek(); err == nil {
+		t.Fatal("Peek() of an emptied stack succeeded")
+	}
+}
+
+func TestLockFreeConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 2000
+	s := NewLockFreeStack[int]()
+
+	var wg sync.WaitGroup
+	popped := make([][]int, workers)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWorker {
+				s.Push(w*perWorker + i)
+				if i%2 == 1 {
+					// two pushed for each pop so far, so the stack is never empty
+					item, err := s.Pop()
+					if err != nil {
+						t.Errorf("Pop: %v", err)
+						return
+					}
+					popped[w] = append(popped[w], item)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	all := slices.Concat(popped...)
+	for {
+		item, err := s.Pop()
+		if err != nil {
+			break
+		}
+		all = append(all, item)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("Len() = %d once drained; want 0", s.Len())
+	}
+	slices.Sort(all)
+	if len(all) != workers*perWorker || len(slices.Compact(all)) != workers*perWorker {
+		t.Fatalf("%d items came back, some of them twice; want each of %d once", len(all), workers*perWorker)
+	}
+}
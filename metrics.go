@@ -0,0 +1,62 @@
+package safestack
+
+import "expvar"
+
+// HighWater - return the greatest # of items the stack has held.
+func (s *SafeStack[T]) HighWater() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return max(s.highest, len(s.Items))
+}
+
+// Stats - a snapshot of the stack's counters; see Stats().
+type Stats struct {
+	Pushes    uint64 // items pushed, not counting those refused by the overflow policy
+	Pops      uint64 // items popped, drained included
+	Evictions uint64 // items dropped to honor Maxsize, a weight budget, Trim() or the like
+	Len       int
+	HighWater int
+}
+
+// opCounts - the running counters behind Stats(); updated under the write lock along with the items
+type opCounts struct {
+	pushes, pops, evictions uint64
+}
+
+// Stats - return the operation counters of the stack; all of them are read at the same moment.
+func (s *SafeStack[T]) Stats() Stats {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return Stats{
+		Pushes:    s.counts.pushes,
+		Pops:      s.counts.pops,
+		Evictions: s.counts.evictions,
+		Len:       len(s.Items),
+		HighWater: max(s.highest, len(s.Items)),
+	}
+}
+
+// RegisterExpvar - publish the counters of the stack as the expvar name:
+// {"len": 3, "cap": 8, "maxsize": 0, "highwater": 5, "pushes": 12, "pops": 9, "evictions": 0}
+// the values are read afresh whenever the var is read; rates follow from sampling the counters. like expvar.Publish()
+// this panics if name is already taken.
+func (s *SafeStack[T]) RegisterExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		s.mutex.RLock()
+		defer s.mutex.RUnlock()
+		return map[string]any{
+			"len":       len(s.Items),
+			"cap":       cap(s.Items),
+			"maxsize":   s.Maxsize,
+			"highwater": max(s.highest, len(s.Items)),
+			"pushes":    s.counts.pushes,
+			"pops":      s.counts.pops,
+			"evictions": s.counts.evictions,
+		}
+	}))
+}
+
+// ExposeExpvar - RegisterExpvar() by another name.
+func (s *SafeStack[T]) ExposeExpvar(name string) {
+	s.RegisterExpvar(name)
+}
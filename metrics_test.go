@@ -0,0 +1,46 @@
+package safestack
+
+import (
+	"encoding/json"
+	"expvar"
+	"strconv"
+	"testing"
+)
+
+// expvarRuns - numbers the vars that TestRegisterExpvar publishes, since expvar names cannot be reused under -count
+var expvarRuns int
+
+func TestRegisterExpvar(t *testing.T) {
+	expvarRuns++
+	name := "safestack_test_expvar_" + strconv.Itoa(expvarRuns)
+	s := NewSafeStackCap[int](8)
+	s.NewMax(4)
+	s.RegisterExpvar(name)
+	s.PushMany([]int{1, 2, 3, 4, 5})
+	_, _ = s.Pop()
+	_, _ = s.Pop()
+
+	v := expvar.Get(name)
+	if v == nil {
+		t.Fatal("the var was not published")
+	}
+	var got map[string]int
+	if err := json.Unmarshal([]byte(v.String()), &got); err != nil {
+		t.Fatalf("the var holds %s: %v", v.String(), err)
+	}
+	want := map[string]int{"len": 2, "maxsize": 4, "highwater": 4, "pushes": 5, "pops": 2, "evictions": 1}
+	for k, w := range want {
+		if got[k] != w {
+			t.Errorf("%s = %d; want %d", k, got[k], w)
+		}
+	}
+	if got["cap"] < got["len"] {
+		t.Errorf("cap = %d is less than len = %d", got["cap"], got["len"])
+	}
+
+	s.Push(6)
+	_ = json.Unmarshal([]byte(v.String()), &got)
+	if got["len"] != 3 || got["pushes"] != 6 {
+		t.Errorf("after another push len = %d and pushes = %d; want 3 and 6, read afresh", got["len"], got["pushes"])
+	}
+}
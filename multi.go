@@ -0,0 +1,217 @@
+package safestack
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+// DrainFairly - pop one item from each non-empty stack in turn, round after round, until all of them are empty.
+// f is called with each popped item while no lock is held.
+// DrainFairly([[1, 2, 3], [4]], f) -> f(3), f(4), f(2), f(1)
+func DrainFairly[T any](stacks []*SafeStack[T], f func(T)) {
+	for {
+		popped := false
+		for _, s := range stacks {
+			s.mutex.Lock()
+			item, err := s.pop()
+			s.unlock()
+			if err == nil {
+				popped = true
+				f(item)
+			}
+		}
+		if !popped {
+			return
+		}
+	}
+}
+
+// PopAny - pop the top item of the first stack in the list that has one, waiting as long as all of them are empty;
+// return the item and the index of its stack. stacks earlier in the list take precedence, so they can serve as
+// priority lanes. gives up with ctx.Err() once ctx is done, and with ErrClosed once every stack is closed.
+func PopAny[T any](ctx context.Context, stacks ...*SafeStack[T]) (T, int, error) {
+	var i T
+	cases := make([]reflect.SelectCase, 0, len(stacks)+1)
+	for {
+		cases = append(cases[:0], reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+		for k, s := range stacks {
+			s.mutex.Lock()
+			if s.closed {
+				s.unlock()
+				continue
+			}
+			if len(s.Items) > 0 {
+				item, err := s.pop()
+				s.unlock()
+				return item, k, err
+			}
+			w := s.waitChan()
+			s.unlock()
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(w)})
+		}
+		if len(cases) == 1 {
+			return i, -1, ErrClosed
+		}
+		if chosen, _, _ := reflect.Select(cases); chosen == 0 {
+			return i, -1, ctx.Err()
+		}
+	}
+}
+
+// lockPair - write-lock dst and lock src (for writing if srcWrite, else for reading), always in the same order,
+// so that two goroutines working on the same pair in opposite directions cannot deadlock; return the matching unlock func,
+// which releases both stacks before it fires the hooks of either. if dst and src are the same stack it is write-locked once.
+func lockPair[T any](dst, src *SafeStack[T], srcWrite bool) func() {
+	if dst == src {
+		dst.mutex.Lock()
+		return dst.unlock
+	}
+
+	lockSrc := src.mutex.RLock
+	if srcWrite {
+		lockSrc = src.mutex.Lock
+	}
+	if uintptr(unsafe.Pointer(dst)) < uintptr(unsafe.Pointer(src)) {
+		dst.mutex.Lock()
+		lockSrc()
+	} else {
+		lockSrc()
+		dst.mutex.Lock()
+	}
+	return func() {
+		// both locks go before any hook fires: a hook of either stack may use the other
+		var fromSrc pending[T]
+		if srcWrite {
+			fromSrc = src.release()
+		} else {
+			src.mutex.RUnlock()
+		}
+		fromDst := dst.release()
+		fromSrc.fire()
+		fromDst.fire()
+	}
+}
+
+// EqualFunc - report whether the two stacks hold the same # of items and eq holds for each pair, bottom to top;
+// both stacks are locked for reading at once, so the comparison is of a single moment.
+func (s *SafeStack[T]) EqualFunc(other *SafeStack[T], eq func(a, b T) bool) bool {
+	if s == other {
+		return true
+	}
+	unlock := readPair(s, other)
+	defer unlock()
+	return slices.EqualFunc(s.Items, other.Items, eq)
+}
+
+// readPair - take the read locks of two distinct stacks in address order, like lockPair(); return the func that releases them
+func readPair[T any](a, b *SafeStack[T]) func() {
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.mutex.RLock()
+	b.mutex.RLock()
+	return func() {
+		b.mutex.RUnlock()
+		a.mutex.RUnlock()
+	}
+}
+
+// Merge - move every item of other onto the top of this stack, bottom first, in one step; other ends up empty.
+// Maxsize and the overflow policy apply to each item as it arrives.
+// Merge(other) on stack [1, 2] with other [3, 4] -> stack [1, 2, 3, 4]; and other is []
+func (s *SafeStack[T]) Merge(other *SafeStack[T]) {
+	unlock := lockPair(s, other, true)
+	defer unlock()
+	if s == other || s.closed {
+		return
+	}
+
+	for _, item := range other.Items {
+		_ = s.push(item)
+	}
+	other.empty()
+}
+
+// MergeInterleaved - Merge() but alternate the items of the two stacks, starting from the bottom; then trim to Maxsize.
+// MergeInterleaved(other) on stack [1, 2, 3] with other [7, 8] -> stack [1, 7, 2, 8, 3]; and other is []
+func (s *SafeStack[T]) MergeInterleaved(other *SafeStack[T]) {
+	unlock := lockPair(s, other, true)
+	defer unlock()
+	if s == other || s.closed {
+		return
+	}
+
+	merged := make([]T, 0, len(s.Items)+len(other.Items))
+	for i := 0; i < max(len(s.Items), len(other.Items)); i++ {
+		if i < len(s.Items) {
+			merged = append(merged, s.Items[i])
+		}
+		if i < len(other.Items) {
+			merged = append(merged, other.Items[i])
+		}
+	}
+
+	s.Items = merged
+	s.journal.reset(s.Items)
+	s.changed()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
+	other.empty()
+}
+
+// SwapContents - exchange the items of this stack and other in one step; each keeps its own settings,
+// so a stack handed more items than its Maxsize allows trims (and evicts) from the bottom.
+// SwapContents(other) on stack [1, 2] with other [3] -> stack [3]; and other is [1, 2]
+func (s *SafeStack[T]) SwapContents(other *SafeStack[T]) {
+	unlock := lockPair(s, other, true)
+	defer unlock()
+	if s == other || s.closed || other.closed {
+		return
+	}
+
+	s.Items, other.Items = other.Items, s.Items
+	for _, st := range []*SafeStack[T]{s, other} {
+		st.journal.reset(st.Items)
+		st.changed()
+		if st.Maxsize > 0 {
+			st.trim(st.Maxsize)
+		}
+	}
+}
+
+// Split - move the bottom n items (all of them, if there are fewer) into a new stack, in one step.
+// Split(2) on stack [1, 2, 3, 4] -> return stack [1, 2]; and now stack is [3, 4]
+func (s *SafeStack[T]) Split(n int) *SafeStack[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+	n = min(max(n, 0), len(s.Items))
+
+	part := make([]T, n)
+	copy(part, s.Items[:n])
+	kept := make([]T, len(s.Items)-n)
+	copy(kept, s.Items[n:])
+	s.Items = kept
+	s.journal.op(opTrim, len(kept))
+	s.changed()
+	return NewSafeStack(part)
+}
+
+// SplitTop - Split() from the other end: move the top n items into a new stack, keeping their order.
+// SplitTop(2) on stack [1, 2, 3, 4] -> return stack [3, 4]; and now stack is [1, 2]
+func (s *SafeStack[T]) SplitTop(n int) *SafeStack[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+	n = min(max(n, 0), len(s.Items))
+
+	k := len(s.Items) - n
+	part := make([]T, n)
+	copy(part, s.Items[k:])
+	clear(s.Items[k:])
+	s.Items = s.Items[:k]
+	s.journal.reset(s.Items)
+	s.changed()
+	return NewSafeStack(part)
+}
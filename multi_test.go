@@ -0,0 +1,160 @@
+package safestack
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDrainFairly(t *testing.T) {
+	stacks := []*SafeStack[int]{
+		NewSafeStack([]int{1, 2, 3}),
+		NewSafeStack([]int{}),
+		NewSafeStack([]int{4}),
+		NewSafeStack([]int{5, 6}),
+	}
+	var got []int
+	DrainFairly(stacks, func(i int) { got = append(got, i) })
+
+	if want := []int{3, 4, 6, 2, 5, 1}; !slices.Equal(got, want) {
+		t.Fatalf("DrainFairly consumed %v; want %v", got, want)
+	}
+	for i, s := range stacks {
+		if s.Len() != 0 {
+			t.Fatalf("stack %d still holds %d items", i, s.Len())
+		}
+	}
+}
+
+func TestDrainFairlyCallbackPushes(t *testing.T) {
+	a := NewSafeStack([]int{1, 2})
+	b := NewSafeStack([]int{})
+	var got []int
+	DrainFairly([]*SafeStack[int]{a, b}, func(i int) {
+		got = append(got, i)
+		if i > 0 {
+			b.Push(-i)
+		}
+	})
+	if want := []int{2, -2, 1, -1}; !slices.Equal(got, want) {
+		t.Fatalf("DrainFairly consumed %v; want %v", got, want)
+	}
+}
+
+// within - fail unless f returns within a second; for operations that would deadlock
+func within(t *testing.T, what string, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("%s deadlocked", what)
+	}
+}
+
+func TestMergeCrossHooks(t *testing.T) {
+	a, b := NewSafeStack([]int{1}), NewSafeStack([]int{2, 3})
+	a.NewMax(2)
+	var lens []int
+	b.OnClear(func() { lens = append(lens, a.Len()) })
+	a.OnEvict(func(int) { lens = append(lens, b.Len()) })
+	within(t, "Merge with hooks that read the other stack", func() { a.Merge(b) })
+	if !slices.Equal(lens, []int{0, 2}) && !slices.Equal(lens, []int{2, 0}) {
+		t.Fatalf("the hooks saw lengths %v; want b's 0 and a's 2", lens)
+	}
+	if got := a.Snapshot(); !slices.Equal(got, []int{2, 3}) {
+		t.Fatalf("a holds %v; want [2 3]", got)
+	}
+}
+
+func TestMergeInterleavedCrossHooks(t *testing.T) {
+	a, b := NewSafeStack([]int{1, 3}), NewSafeStack([]int{2})
+	var seen []int
+	b.OnClear(func() { seen = a.Snapshot() })
+	within(t, "MergeInterleaved with a hook that reads the other stack", func() { a.MergeInterleaved(b) })
+	if !slices.Equal(seen, []int{1, 2, 3}) {
+		t.Fatalf("b's OnClear saw a as %v; want [1 2 3]", seen)
+	}
+}
+
+func TestSwapContentsCrossHooks(t *testing.T) {
+	a, b := NewSafeStack([]int{1, 2, 3}), NewSafeStack([]int{4, 5, 6})
+	a.Maxsize, b.Maxsize = 2, 2
+	var fromA, fromB []int
+	a.OnEvict(func(int) { fromA = b.Snapshot() })
+	b.OnEvict(func(int) { fromB = a.Snapshot() })
+	within(t, "SwapContents with hooks on both sides that read the other stack", func() { a.SwapContents(b) })
+	if !slices.Equal(fromA, []int{2, 3}) || !slices.Equal(fromB, []int{5, 6}) {
+		t.Fatalf("a's OnEvict saw b as %v and b's saw a as %v; want [2 3] and [5 6]", fromA, fromB)
+	}
+}
+
+func TestPopAnyPrecedence(t *testing.T) {
+	high, low := NewSafeStack([]int{}), NewSafeStack([]int{1, 2})
+	if item, k, err := PopAny(context.Background(), high, low); err != nil || item != 2 || k != 1 {
+		t.Fatalf("PopAny() = %d from %d, %v; want 2 from 1, the only stack with items", item, k, err)
+	}
+	high.Push(9)
+	if item, k, err := PopAny(context.Background(), high, low); err != nil || item != 9 || k != 0 {
+		t.Fatalf("PopAny() = %d from %d, %v; want 9 from 0, the first stack in the list", item, k, err)
+	}
+}
+
+func TestPopAnyWaits(t *testing.T) {
+	a, b := NewSafeStack([]int{}), NewSafeStack([]int{})
+	type result struct{ item, k int }
+	got := make(chan result)
+	go func() {
+		item, k, err := PopAny(context.Background(), a, b)
+		if err != nil {
+			t.Errorf("PopAny: %v", err)
+		}
+		got <- result{item, k}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	b.Push(5)
+	select {
+	case r := <-got:
+		if r.item != 5 || r.k != 1 {
+			t.Fatalf("PopAny() = %d from %d; want 5 from 1", r.item, r.k)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("PopAny() never woke for a push")
+	}
+	if b.Len() != 0 {
+		t.Fatalf("Len() = %d after PopAny(); want 0", b.Len())
+	}
+}
+
+func TestPopAnyGivesUp(t *testing.T) {
+	a, b := NewSafeStack([]int{}), NewSafeStack([]int{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, k, err := PopAny(ctx, a, b); !errors.Is(err, context.DeadlineExceeded) || k != -1 {
+		t.Fatalf("PopAny() of empty stacks = index %d, %v; want -1 and the deadline", k, err)
+	}
+
+	// closing the last open stack wakes a waiting PopAny
+	_ = a.Close()
+	errs := make(chan error)
+	go func() {
+		_, _, err := PopAny(context.Background(), a, b)
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	_ = b.Close()
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("PopAny() once every stack closed: %v; want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("PopAny() never woke for Close()")
+	}
+}
@@ -0,0 +1,118 @@
+package safestack
+
+// Option - a setting for NewSafeStackWith()
+type Option[T any] func(*SafeStack[T])
+
+// NewSafeStackWith - the factory function with options; the stack is fully configured before any other goroutine can see it.
+// NewSafeStackWith(WithMax[int](100), WithOverflowPolicy[int](Reject))
+func NewSafeStackWith[T any](opts ...Option[T]) *SafeStack[T] {
+	s := NewSafeStack([]T{})
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mutex.Lock()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
+	if s.maxWeight > 0 {
+		s.evictToBytes(s.maxWeight)
+	}
+	s.unlock()
+	return s
+}
+
+// WithMax - set Maxsize; WithItems() beyond it are trimmed from the bottom.
+func WithMax[T any](n int) Option[T] {
+	return func(s *SafeStack[T]) { s.Maxsize = n }
+}
+
+// WithCapacity - preallocate room for n items.
+func WithCapacity[T any](n int) Option[T] {
+	return func(s *SafeStack[T]) {
+		if n > cap(s.Items) {
+			items := make([]T, len(s.Items), n)
+			copy(items, s.Items)
+			s.Items = items
+		}
+	}
+}
+
+// WithItems - start out holding a copy of items, items[len(items)-1] on top.
+func WithItems[T any](items []T) Option[T] {
+	return func(s *SafeStack[T]) { s.Items = append(s.Items, items...) }
+}
+
+// WithOverflowPolicy - see SetOverflowPolicy().
+func WithOverflowPolicy[T any](p OverflowPolicy) Option[T] {
+	return func(s *SafeStack[T]) { s.overflow = p }
+}
+
+// WithEvictHook - see OnEvict().
+func WithEvictHook[T any](f func(item T)) Option[T] {
+	return func(s *SafeStack[T]) { s.onEvict = f }
+}
+
+// WithEmptyBehavior - see SetEmptyBehavior().
+func WithEmptyBehavior[T any](b EmptyBehavior) Option[T] {
+	return func(s *SafeStack[T]) { s.onEmpty = b }
+}
+
+// WithAutoGrow - see SetAutoGrow().
+func WithAutoGrow[T any](factor float64, limit int) Option[T] {
+	return func(s *SafeStack[T]) { s.grow = autoGrow{factor: factor, limit: limit} }
+}
+
+// WithSizeFunc - see SetSizeFunc().
+func WithSizeFunc[T any](f func(T) int) Option[T] {
+	return func(s *SafeStack[T]) { s.sizeof = f }
+}
+
+// WithMaxWeight - see SetMaxWeight().
+func WithMaxWeight[T any](budget int) Option[T] {
+	return func(s *SafeStack[T]) { s.maxWeight = max(budget, 0) }
+}
+
+// WithMaxBytes - see SetMaxBytes().
+func WithMaxBytes[T any](budget int) Option[T] {
+	return func(s *SafeStack[T]) {
+		if s.sizeof == nil {
+			s.sizeof = bytesOf[T]
+		}
+		s.maxWeight = max(budget, 0)
+	}
+}
+
+// WithTracer - see SetTracer().
+func WithTracer[T any](t Tracer[T]) Option[T] {
+	return func(s *SafeStack[T]) { s.tracer = t }
+}
+
+// WithPushHook - see OnPush().
+func WithPushHook[T any](f func(item T)) Option[T] {
+	return func(s *SafeStack[T]) { s.hooks.push = f }
+}
+
+// WithPopHook - see OnPop().
+func WithPopHook[T any](f func(item T)) Option[T] {
+	return func(s *SafeStack[T]) { s.hooks.pop = f }
+}
+
+// WithClearHook - see OnClear().
+func WithClearHook[T any](f func()) Option[T] {
+	return func(s *SafeStack[T]) { s.hooks.clear = f }
+}
+
+// WithCopyOnWrite - see SetCopyOnWrite().
+func WithCopyOnWrite[T any]() Option[T] {
+	return func(s *SafeStack[T]) { s.cow, s.stale = true, true }
+}
+
+// WithAutoShrink - see SetAutoShrink().
+func WithAutoShrink[T any]() Option[T] {
+	return func(s *SafeStack[T]) { s.shrink = true }
+}
+
+// WithPopLimiter - see SetPopLimiter().
+func WithPopLimiter[T any](l Limiter) Option[T] {
+	return func(s *SafeStack[T]) { s.limiter = l }
+}
@@ -0,0 +1,118 @@
+package safestack
+
+import (
+	"cmp"
+	"fmt"
+	"sync"
+)
+
+// OrderedSafeStack - a stack of ordered items that also knows its least and greatest item at every moment;
+// Min() and Max() cost O(1) because each entry records the extremes of itself and everything beneath it.
+type OrderedSafeStack[T cmp.Ordered] struct {
+	entries []orderedEntry[T]
+	mutex   sync.RWMutex
+}
+
+// orderedEntry - an item along with the min and max of the stack up to and including it
+type orderedEntry[T cmp.Ordered] struct {
+	item, min, max T
+}
+
+// NewOrderedSafeStack - the factory function; return a *OrderedSafeStack[T] holding a copy of items
+func NewOrderedSafeStack[T cmp.Ordered](items []T) *OrderedSafeStack[T] {
+	s := &OrderedSafeStack[T]{entries: make([]orderedEntry[T], 0, len(items))}
+	for _, item := range items {
+		s.push(item)
+	}
+	return s
+}
+
+// Push - add an item to the top of the stack.
+func (s *OrderedSafeStack[T]) Push(item T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.push(item)
+}
+
+// push - the body of Push(); the caller holds the write lock
+func (s *OrderedSafeStack[T]) push(item T) {
+	e := orderedEntry[T]{item: item, min: item, max: item}
+	if n := len(s.entries); n > 0 {
+		e.min = min(item, s.entries[n-1].min)
+		e.max = max(item, s.entries[n-1].max)
+	}
+	s.entries = append(s.entries, e)
+}
+
+// Pop - pop the top item from the stack leaving it smaller by one.
+// Pop() from stack [1, 2, 3] -> return 3; and now stack is [1, 2].
+func (s *OrderedSafeStack[T]) Pop() (T, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var i T
+	if len(s.entries) == 0 {
+		return i, fmt.Errorf("empty stack")
+	}
+
+	i = s.entries[len(s.entries)-1].item
+	clear(s.entries[len(s.entries)-1:])
+	s.entries = s.entries[:len(s.entries)-1]
+	return i, nil
+}
+
+// Peek - look at the top item in the stack; but do not pop it.
+// Peek() from stack [1, 2, 3] -> return 3; and stack is still [1, 2, 3]
+func (s *OrderedSafeStack[T]) Peek() (T, error) {
+	return s.top(func(e orderedEntry[T]) T { return e.item })
+}
+
+// Min - return the least item in the stack, in O(1).
+// Min() on stack [2, 1, 3] -> return 1
+func (s *OrderedSafeStack[T]) Min() (T, error) {
+	return s.top(func(e orderedEntry[T]) T { return e.min })
+}
+
+// Max - return the greatest item in the stack, in O(1).
+// Max() on stack [2, 3, 1] -> return 3
+func (s *OrderedSafeStack[T]) Max() (T, error) {
+	return s.top(func(e orderedEntry[T]) T { return e.max })
+}
+
+// top - return a field of the top entry under the read lock
+func (s *OrderedSafeStack[T]) top(field func(orderedEntry[T]) T) (T, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var i T
+	if len(s.entries) == 0 {
+		return i, fmt.Errorf("empty stack")
+	}
+	return field(s.entries[len(s.entries)-1]), nil
+}
+
+// Len - return the # of items in the stack.
+func (s *OrderedSafeStack[T]) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.entries)
+}
+
+// PeekAll - return all items in the stack but leave the stack unchanged; last in first out.
+// Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> PeekAll() returns [3, 2, 1]
+func (s *OrderedSafeStack[T]) PeekAll() []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	all := make([]T, len(s.entries))
+	for i, e := range s.entries {
+		all[len(all)-1-i] = e.item
+	}
+	return all
+}
+
+// Clear - empty the stack.
+func (s *OrderedSafeStack[T]) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = []orderedEntry[T]{}
+}
@@ -0,0 +1,34 @@
+package safestack
+
+import "errors"
+
+// ErrFull - returned by PushErr() when the overflow policy is ReturnError and the stack is full
+var ErrFull = errors.New("stack full")
+
+// OverflowPolicy - what a push onto a full stack does; see SetOverflowPolicy()
+type OverflowPolicy int
+
+const (
+	DropOldest  OverflowPolicy = iota // evict the bottom item to make room; the default
+	DropNewest                        // evict the top item to make room
+	Reject                            // drop the pushed item instead
+	ReturnError                       // drop the pushed item instead and have PushErr() return ErrFull
+)
+
+// SetOverflowPolicy - choose what pushing onto a full stack does.
+func (s *SafeStack[T]) SetOverflowPolicy(p OverflowPolicy) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.overflow = p
+}
+
+// PushErr - Push() but return ErrFull if the ReturnError policy refused the item and ErrClosed on a closed stack.
+func (s *SafeStack[T]) PushErr(item T) error {
+	s.mutex.Lock()
+	defer s.unlock()
+	err := s.push(item)
+	if errors.Is(err, ErrFull) && s.overflow != ReturnError {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,83 @@
+package safestack
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Codec - how SaveToFile() and LoadFromFile() turn a stack into bytes and back
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec - a Codec writing the JSON of MarshalJSON(); human-readable
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// GobCodec - a Codec writing gob; smaller and faster than JSON
+type GobCodec struct{}
+
+func (GobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+func (GobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// SaveToFile - write the items and Maxsize of the stack to path with the codec; a nil codec means JSONCodec.
+// the file is replaced atomically: a crash mid-save leaves the previous snapshot intact.
+func (s *SafeStack[T]) SaveToFile(path string, c Codec) error {
+	if c == nil {
+		c = JSONCodec{}
+	}
+	data, err := c.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// LoadFromFile - return a new stack read from a file written by SaveToFile() with the same codec.
+func LoadFromFile[T any](path string, c Codec) (*SafeStack[T], error) {
+	if c == nil {
+		c = JSONCodec{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := NewSafeStack([]T{})
+	if err = c.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// writeFileAtomic - write data to a temporary file beside path, sync it, and rename it over path
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
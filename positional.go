@@ -0,0 +1,102 @@
+package safestack
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// PeekAt - look at the item i places below the top of the stack; but do not pop it.
+// PeekAt(1) from stack [1, 2, 3] -> return 2; and stack is still [1, 2, 3]
+func (s *SafeStack[T]) PeekAt(i int) (T, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var item T
+	k, err := s.slot(i)
+	if err != nil {
+		return item, err
+	}
+	return s.Items[k], nil
+}
+
+// At - the same as PeekAt(); named to match FrozenStack.At().
+func (s *SafeStack[T]) At(i int) (T, error) {
+	return s.PeekAt(i)
+}
+
+// SetAt - replace the item i places below the top of the stack with v.
+// SetAt(1, 7) on stack [1, 2, 3] -> stack [1, 7, 3]
+func (s *SafeStack[T]) SetAt(i int, v T) error {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	k, err := s.slot(i)
+	if err != nil {
+		return err
+	}
+	s.Items[k] = v
+	s.journal.reset(s.Items)
+	s.changed()
+	return nil
+}
+
+// InsertAt - insert item so that it ends up i places below the top of the stack; InsertAt(0, item) is PushErr(item).
+// Maxsize, the overflow policy, Stats(), the Tracer, OnPush and subscribers all treat it as a push.
+// InsertAt(1, 7) on stack [1, 2, 3] -> stack [1, 2, 7, 3]
+func (s *SafeStack[T]) InsertAt(i int, item T) error {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	if i < 0 || i > len(s.Items) {
+		return fmt.Errorf("index %d out of range for stack of %d", i, len(s.Items))
+	}
+	err := s.insert(i, item)
+	if errors.Is(err, ErrFull) && s.overflow != ReturnError {
+		return nil
+	}
+	return err
+}
+
+// RemoveAt - remove and return the item i places below the top of the stack; the rest keep their order.
+// RemoveAt(1) on stack [1, 2, 3] -> return 2; and now stack is [1, 3]
+func (s *SafeStack[T]) RemoveAt(i int) (T, error) {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	var item T
+	k, err := s.slot(i)
+	if err != nil {
+		return item, err
+	}
+	item = s.Items[k]
+	s.Items = slices.Delete(s.Items, k, k+1)
+	s.journal.reset(s.Items)
+	s.changed()
+	return item, nil
+}
+
+// MoveToTop - move the item i places below the top of the stack to the top, in one step.
+// MoveToTop(2) on stack [1, 2, 3] -> stack [2, 3, 1]
+func (s *SafeStack[T]) MoveToTop(i int) error {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	k, err := s.slot(i)
+	if err != nil || i == 0 {
+		return err
+	}
+	item := s.Items[k]
+	copy(s.Items[k:], s.Items[k+1:])
+	s.Items[len(s.Items)-1] = item
+	s.rewroteTop(i + 1)
+	return nil
+}
+
+// slot - map a depth counted from the top onto an index into Items; the caller holds a lock
+func (s *SafeStack[T]) slot(i int) (int, error) {
+	if i < 0 || i >= len(s.Items) {
+		return 0, fmt.Errorf("index %d out of range for stack of %d", i, len(s.Items))
+	}
+	return len(s.Items) - 1 - i, nil
+}
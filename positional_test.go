@@ -0,0 +1,103 @@
+package safestack
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestInsertAtIsAPush(t *testing.T) {
+	var log traceLog
+	var hooked []int
+	var journal bytes.Buffer
+	s := NewSafeStack([]int{1, 2, 3})
+	s.SetTracer(&log)
+	s.OnPush(func(item int) { hooked = append(hooked, item) })
+	s.SetJournal(&journal, encodeInt)
+	ch, cancel := s.Subscribe()
+	defer cancel()
+	before := s.Stats()
+
+	if err := s.InsertAt(1, 7); err != nil {
+		t.Fatalf("InsertAt(1, 7): %v", err)
+	}
+	if err := s.InsertAt(4, 0); err != nil {
+		t.Fatalf("InsertAt(4, 0): %v", err)
+	}
+	if err := s.InsertAt(6, 9); err == nil {
+		t.Fatal("InsertAt(6) of 5 items succeeded")
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{0, 1, 2, 7, 3}) {
+		t.Fatalf("stack holds %v; want [0 1 2 7 3]", got)
+	}
+
+	if n := s.Stats().Pushes - before.Pushes; n != 2 {
+		t.Errorf("Stats() counted %d pushes; want 2", n)
+	}
+	if !slices.Equal(log.pushed, []int{7, 0}) {
+		t.Errorf("Tracer.Pushed() saw %v; want [7 0]", log.pushed)
+	}
+	if !slices.Equal(hooked, []int{7, 0}) {
+		t.Errorf("OnPush saw %v; want [7 0]", hooked)
+	}
+	for _, want := range []int{7, 0} {
+		select {
+		case got := <-ch:
+			if got != want {
+				t.Errorf("subscriber got %d; want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("subscriber never got %d", want)
+		}
+	}
+	r, err := Replay(&journal, decodeInt)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if got := r.Snapshot(); !slices.Equal(got, s.Snapshot()) {
+		t.Errorf("replayed journal holds %v; want %v", got, s.Snapshot())
+	}
+}
+
+func TestInsertAtQueueLatency(t *testing.T) {
+	var log traceLog
+	s := NewSafeStack([]int{})
+	s.SetTracer(&log)
+	s.Push(1)
+	s.Push(2)
+	time.Sleep(20 * time.Millisecond)
+	_ = s.InsertAt(1, 3)
+
+	// each item keeps its own push time, wherever it went in
+	for range 3 {
+		_, _ = s.Pop()
+	}
+	if !slices.Equal(log.popped, []int{2, 3, 1}) {
+		t.Fatalf("Popped() saw %v; want [2 3 1]", log.popped)
+	}
+	if q := log.queued[1]; q <= 0 || q >= 20*time.Millisecond {
+		t.Errorf("3 was queued for %v; want a moment", q)
+	}
+	if q := log.queued[2]; q < 20*time.Millisecond {
+		t.Errorf("1 was queued for %v; want at least 20ms", q)
+	}
+}
+
+func TestInsertAtMaxsize(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3})
+	s.NewMax(3)
+	var evicted evictLog[int]
+	s.OnEvict(evicted.hook)
+	if err := s.InsertAt(1, 7); err != nil {
+		t.Fatalf("InsertAt(1, 7): %v", err)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{2, 7, 3}) || !slices.Equal(evicted.get(), []int{1}) {
+		t.Fatalf("stack holds %v, evicted %v; want [2 7 3] having dropped 1", got, evicted.get())
+	}
+
+	s.SetOverflowPolicy(ReturnError)
+	if err := s.InsertAt(0, 8); err != ErrFull {
+		t.Fatalf("InsertAt() into a full stack under ReturnError: %v; want ErrFull", err)
+	}
+}
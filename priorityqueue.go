@@ -0,0 +1,86 @@
+package safestack
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+)
+
+// SafePriorityQueue - a heap locked with a mutex: Pop() returns the highest-priority item, whatever the push order.
+type SafePriorityQueue[T any] struct {
+	h     pqHeap[T]
+	mutex sync.RWMutex
+}
+
+// pqHeap - the heap.Interface behind a SafePriorityQueue
+type pqHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *pqHeap[T]) Len() int           { return len(h.items) }
+func (h *pqHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *pqHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *pqHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *pqHeap[T]) Pop() any {
+	last := len(h.items) - 1
+	x := h.items[last]
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+	return x
+}
+
+// NewSafePriorityQueue - the factory function; less(a, b) reports whether a has priority over b.
+// the queue starts out with a copy of items.
+// NewSafePriorityQueue(func(a, b int) bool { return a > b }, [1, 5, 3]) -> Pop() returns 5, then 3, then 1
+func NewSafePriorityQueue[T any](less func(a, b T) bool, items []T) *SafePriorityQueue[T] {
+	q := &SafePriorityQueue[T]{h: pqHeap[T]{items: make([]T, len(items)), less: less}}
+	copy(q.h.items, items)
+	heap.Init(&q.h)
+	return q
+}
+
+// Push - add an item to the queue.
+func (q *SafePriorityQueue[T]) Push(item T) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	heap.Push(&q.h, item)
+}
+
+// Pop - take the highest-priority item from the queue.
+func (q *SafePriorityQueue[T]) Pop() (T, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	var i T
+	if len(q.h.items) == 0 {
+		return i, fmt.Errorf("empty queue")
+	}
+	return heap.Pop(&q.h).(T), nil
+}
+
+// Peek - look at the highest-priority item; but do not pop it.
+func (q *SafePriorityQueue[T]) Peek() (T, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	var i T
+	if len(q.h.items) == 0 {
+		return i, fmt.Errorf("empty queue")
+	}
+	return q.h.items[0], nil
+}
+
+// Len - return the # of items in the queue.
+func (q *SafePriorityQueue[T]) Len() int {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	return len(q.h.items)
+}
+
+// Clear - empty the queue.
+func (q *SafePriorityQueue[T]) Clear() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.h.items = []T{}
+}
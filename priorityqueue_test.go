@@ -0,0 +1,72 @@
+package safestack
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueueOrder(t *testing.T) {
+	rng := rand.New(rand.NewPCG(264, 264))
+	initial := make([]int, 50)
+	for i := range initial {
+		initial[i] = rng.IntN(100)
+	}
+	want := slices.Clone(initial)
+	q := NewSafePriorityQueue(func(a, b int) bool { return a > b }, initial)
+	initial[0] = -1 // the queue holds a copy
+
+	for range 200 {
+		// pushes between pops reorder the heap from the middle, not just from a fresh heap.Init
+		if rng.IntN(3) > 0 {
+			v := rng.IntN(100)
+			q.Push(v)
+			want = append(want, v)
+			continue
+		}
+		slices.SortFunc(want, func(a, b int) int { return cmp.Compare(b, a) })
+		if len(want) == 0 {
+			continue
+		}
+		if top, err := q.Peek(); err != nil || top != want[0] {
+			t.Fatalf("Peek() = %d, %v; want %d", top, err, want[0])
+		}
+		if top, err := q.Pop(); err != nil || top != want[0] {
+			t.Fatalf("Pop() = %d, %v; want %d", top, err, want[0])
+		}
+		want = want[1:]
+	}
+
+	// what is left comes out in order too
+	slices.SortFunc(want, func(a, b int) int { return cmp.Compare(b, a) })
+	for _, w := range want {
+		if top, err := q.Pop(); err != nil || top != w {
+			t.Fatalf("Pop() = %d, %v; want %d", top, err, w)
+		}
+	}
+	if _, err := q.Pop(); err == nil || q.Len() != 0 {
+		t.Fatalf("Pop() of a drained queue succeeded, or Len() = %d", q.Len())
+	}
+}
+
+func TestPriorityQueueClear(t *testing.T) {
+	type job struct {
+		name string
+		pri  int
+	}
+	q := NewSafePriorityQueue(func(a, b job) bool { return a.pri < b.pri }, nil)
+	q.Push(job{"later", 5})
+	q.Push(job{"first", 1})
+	if j, err := q.Peek(); err != nil || j.name != "first" {
+		t.Fatalf("Peek() = %v, %v; want the job of priority 1", j, err)
+	}
+	q.Clear()
+	if _, err := q.Peek(); err == nil || q.Len() != 0 {
+		t.Fatalf("Peek() of a cleared queue succeeded, or Len() = %d", q.Len())
+	}
+	q.Push(job{"again", 3})
+	if j, err := q.Pop(); err != nil || j.name != "again" {
+		t.Fatalf("Pop() after Clear() = %v, %v; want again", j, err)
+	}
+}
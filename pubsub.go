@@ -0,0 +1,84 @@
+package safestack
+
+import "sync"
+
+// subscriber - one receiver of Subscribe(); its own goroutine forwards the queued items to ch in push order,
+// so the pushers never wait on a slow reader
+type subscriber[T any] struct {
+	ch     chan T
+	mutex  sync.Mutex
+	queue  []T
+	signal chan struct{}
+	quit   chan struct{}
+	once   sync.Once
+}
+
+// notify - queue an item for delivery; called with the stack's write lock held, which keeps push order
+func (sub *subscriber[T]) notify(item T) {
+	sub.mutex.Lock()
+	sub.queue = append(sub.queue, item)
+	sub.mutex.Unlock()
+	select {
+	case sub.signal <- struct{}{}:
+	default:
+	}
+}
+
+// run - forward queued items to ch until stopped; then close ch
+func (sub *subscriber[T]) run() {
+	defer close(sub.ch)
+	for {
+		sub.mutex.Lock()
+		queued := sub.queue
+		sub.queue = nil
+		sub.mutex.Unlock()
+
+		for _, item := range queued {
+			select {
+			case sub.ch <- item:
+			case <-sub.quit:
+				return
+			}
+		}
+
+		select {
+		case <-sub.signal:
+		case <-sub.quit:
+			return
+		}
+	}
+}
+
+func (sub *subscriber[T]) stop() {
+	sub.once.Do(func() { close(sub.quit) })
+}
+
+// Subscribe - receive a copy of every item pushed from now on, in push order, even those evicted straight away;
+// call the returned func to unsubscribe, which closes the channel, as does Close(); either drops undelivered items.
+// items wait in an unbounded queue until read, so a subscriber that stops reading must unsubscribe.
+func (s *SafeStack[T]) Subscribe() (<-chan T, func()) {
+	sub := &subscriber[T]{
+		ch:     make(chan T),
+		signal: make(chan struct{}, 1),
+		quit:   make(chan struct{}),
+	}
+	go sub.run()
+
+	s.mutex.Lock()
+	if s.closed {
+		sub.stop()
+	} else {
+		if s.subs == nil {
+			s.subs = make(map[*subscriber[T]]bool)
+		}
+		s.subs[sub] = true
+	}
+	s.unlock()
+
+	return sub.ch, func() {
+		s.mutex.Lock()
+		delete(s.subs, sub)
+		s.unlock()
+		sub.stop()
+	}
+}
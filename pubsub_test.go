@@ -0,0 +1,100 @@
+package safestack
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// receive - read n items from ch, failing the test if they take more than a second
+func receive[T any](t *testing.T, ch <-chan T, n int) []T {
+	t.Helper()
+	var got []T
+	timeout := time.After(time.Second)
+	for len(got) < n {
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				t.Fatalf("channel closed after %d items; want %d", len(got), n)
+			}
+			got = append(got, item)
+		case <-timeout:
+			t.Fatalf("received %d items; want %d", len(got), n)
+		}
+	}
+	return got
+}
+
+// requireClosed - fail the test unless ch is closed within a second, discarding whatever is still in it
+func requireClosed[T any](t *testing.T, ch <-chan T) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("channel still open")
+		}
+	}
+}
+
+func TestSubscribeFanOut(t *testing.T) {
+	s := NewSafeStack([]int{0})
+	s.NewMax(2)
+	a, unsubA := s.Subscribe()
+	defer unsubA()
+	b, unsubB := s.Subscribe()
+	defer unsubB()
+
+	// nobody reads yet, and nothing waits on the readers; Maxsize evicts most of these at once
+	for i := 1; i <= 100; i++ {
+		s.Push(i)
+	}
+	s.PushMany([]int{101, 102})
+
+	want := make([]int, 102)
+	for i := range want {
+		want[i] = i + 1
+	}
+	for name, ch := range map[string]<-chan int{"a": a, "b": b} {
+		if got := receive(t, ch, len(want)); !slices.Equal(got, want) {
+			t.Fatalf("subscriber %s got %v; want 1..102 in push order", name, got)
+		}
+	}
+}
+
+func TestSubscribeUnsubscribe(t *testing.T) {
+	s := NewSafeStack([]int{})
+	a, unsubA := s.Subscribe()
+	b, unsubB := s.Subscribe()
+	defer unsubB()
+
+	s.Push(1)
+	if got := receive(t, a, 1); got[0] != 1 {
+		t.Fatalf("subscriber a got %v; want [1]", got)
+	}
+	unsubA()
+	unsubA() // a second call is harmless
+	requireClosed(t, a)
+
+	s.Push(2)
+	if got := receive(t, b, 2); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("subscriber b got %v; want [1 2]", got)
+	}
+}
+
+func TestSubscribeClose(t *testing.T) {
+	s := NewSafeStack([]int{})
+	ch, unsub := s.Subscribe()
+	defer unsub()
+	s.Push(1)
+	_ = s.Close()
+	requireClosed(t, ch)
+
+	late, unsubLate := s.Subscribe()
+	defer unsubLate()
+	requireClosed(t, late)
+}
@@ -0,0 +1,168 @@
+// Package remote shares a SafeStack between processes over the network, via net/rpc with gob-encoded items.
+// a Server wraps the stack; any number of Clients, in any process, push and pop it as if it were local.
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"net"
+	"net/rpc"
+	"reflect"
+
+	"github.com/e-gun/safestack"
+)
+
+// serviceName - the name the stack is registered under with net/rpc
+const serviceName = "Stack"
+
+// Empty - the argument or reply of calls that need none
+type Empty struct{}
+
+// Server - serves one SafeStack to remote Clients.
+type Server[T any] struct {
+	rpc *rpc.Server
+}
+
+// NewServer - the factory function; return a *Server[T] for s. the items travel gob-encoded, so T must be gob-encodable,
+// and it must be exported or builtin: net/rpc would otherwise quietly leave out Push, Pop and Peek, so that is an error.
+func NewServer[T any](s *safestack.SafeStack[T]) (*Server[T], error) {
+	if t := reflect.TypeFor[T](); !exportedOrBuiltin(t) {
+		return nil, fmt.Errorf("remote: item type %v is not exported, and net/rpc serves no method that takes it", t)
+	}
+	srv := &Server[T]{rpc: rpc.NewServer()}
+	if err := srv.rpc.RegisterName(serviceName, &service[T]{s: s}); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// exportedOrBuiltin - the test net/rpc applies to the argument and reply types of a method before serving it
+func exportedOrBuiltin(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return token.IsExported(t.Name()) || t.PkgPath() == ""
+}
+
+// Serve - accept connections on l and serve each of them from a goroutine of its own; returns once l is closed.
+func (srv *Server[T]) Serve(l net.Listener) {
+	srv.rpc.Accept(l)
+}
+
+// ServeConn - serve a single connection, blocking until the client hangs up.
+func (srv *Server[T]) ServeConn(conn net.Conn) {
+	srv.rpc.ServeConn(conn)
+}
+
+// service - the methods net/rpc exposes; each calls the SafeStack method of the same name
+type service[T any] struct {
+	s *safestack.SafeStack[T]
+}
+
+func (v *service[T]) Push(item T, _ *Empty) error {
+	return v.s.PushErr(item)
+}
+
+func (v *service[T]) Pop(_ Empty, item *T) (err error) {
+	*item, err = v.s.Pop()
+	return err
+}
+
+func (v *service[T]) Peek(_ Empty, item *T) (err error) {
+	*item, err = v.s.Peek()
+	return err
+}
+
+func (v *service[T]) Len(_ Empty, n *int) error {
+	*n = v.s.Len()
+	return nil
+}
+
+func (v *service[T]) PeekAll(_ Empty, items *[]T) error {
+	*items = v.s.PeekAll()
+	return nil
+}
+
+func (v *service[T]) Clear(_ Empty, _ *Empty) error {
+	v.s.Clear()
+	return nil
+}
+
+// Client - a stack served by a Server elsewhere. its methods are those of SafeStack, plus an error for the network.
+// errors of the stack itself, an empty stack say, arrive as rpc.ServerError; ErrFull and ErrClosed are translated back.
+type Client[T any] struct {
+	rpc *rpc.Client
+}
+
+// Dial - connect to the Server at address on the named network, e.g. Dial[int]("tcp", "localhost:7070").
+func Dial[T any](network, address string) (*Client[T], error) {
+	c, err := rpc.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &Client[T]{rpc: c}, nil
+}
+
+// NewClient - a Client talking over an established connection.
+func NewClient[T any](conn net.Conn) *Client[T] {
+	return &Client[T]{rpc: rpc.NewClient(conn)}
+}
+
+// Close - hang up.
+func (c *Client[T]) Close() error {
+	return c.rpc.Close()
+}
+
+// Push - add an item to the top of the remote stack.
+func (c *Client[T]) Push(item T) error {
+	return c.call("Push", item, &Empty{})
+}
+
+// Pop - pop the top item from the remote stack.
+func (c *Client[T]) Pop() (T, error) {
+	var item T
+	err := c.call("Pop", Empty{}, &item)
+	return item, err
+}
+
+// Peek - look at the top item in the remote stack; but do not pop it.
+func (c *Client[T]) Peek() (T, error) {
+	var item T
+	err := c.call("Peek", Empty{}, &item)
+	return item, err
+}
+
+// Len - return the # of items in the remote stack.
+func (c *Client[T]) Len() (int, error) {
+	var n int
+	err := c.call("Len", Empty{}, &n)
+	return n, err
+}
+
+// PeekAll - return all items in the remote stack, last in first out.
+func (c *Client[T]) PeekAll() ([]T, error) {
+	var items []T
+	err := c.call("PeekAll", Empty{}, &items)
+	return items, err
+}
+
+// Clear - empty the remote stack.
+func (c *Client[T]) Clear() error {
+	return c.call("Clear", Empty{}, &Empty{})
+}
+
+// call - make a call and turn the sentinel errors of the stack back into themselves
+func (c *Client[T]) call(method string, args, reply any) error {
+	err := c.rpc.Call(serviceName+"."+method, args, reply)
+	var se rpc.ServerError
+	if errors.As(err, &se) {
+		switch string(se) {
+		case safestack.ErrFull.Error():
+			return safestack.ErrFull
+		case safestack.ErrClosed.Error():
+			return safestack.ErrClosed
+		}
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package remote
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/e-gun/safestack"
+)
+
+// Job - an exported item type, which net/rpc will serve
+type Job struct {
+	ID   int
+	Name string
+}
+
+// job - an unexported one, which it will not
+type job struct {
+	ID int
+}
+
+// serve - start a Server for s on a loopback port and return a Client connected to it
+func serve[T any](t *testing.T, s *safestack.SafeStack[T]) *Client[T] {
+	t.Helper()
+	srv, err := NewServer(s)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go srv.Serve(l)
+
+	c, err := Dial[T]("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestLoopbackExported(t *testing.T) {
+	s := safestack.NewSafeStack([]Job{})
+	c := serve(t, s)
+
+	for i, name := range []string{"a", "b", "c"} {
+		if err := c.Push(Job{ID: i, Name: name}); err != nil {
+			t.Fatalf("Push: %v", err)
+		}
+	}
+	if n, err := c.Len(); err != nil || n != 3 {
+		t.Fatalf("Len() = %d, %v; want 3", n, err)
+	}
+	if j, err := c.Peek(); err != nil || j.Name != "c" {
+		t.Fatalf("Peek() = %v, %v; want c", j, err)
+	}
+	if j, err := c.Pop(); err != nil || j != (Job{ID: 2, Name: "c"}) {
+		t.Fatalf("Pop() = %v, %v; want {2 c}", j, err)
+	}
+	all, err := c.PeekAll()
+	if err != nil || len(all) != 2 || all[0].Name != "b" || all[1].Name != "a" {
+		t.Fatalf("PeekAll() = %v, %v; want [b a]", all, err)
+	}
+	if s.Len() != 2 {
+		t.Fatalf("local Len() = %d; want 2", s.Len())
+	}
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if _, err := c.Pop(); err == nil {
+		t.Fatal("Pop() of an empty stack succeeded")
+	}
+}
+
+func TestLoopbackBuiltin(t *testing.T) {
+	c := serve(t, safestack.NewSafeStack([]int{}))
+	if err := c.Push(7); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if i, err := c.Pop(); err != nil || i != 7 {
+		t.Fatalf("Pop() = %d, %v; want 7", i, err)
+	}
+}
+
+func TestLoopbackSentinels(t *testing.T) {
+	s := safestack.NewSafeStack([]int{})
+	s.NewMax(1)
+	s.SetOverflowPolicy(safestack.ReturnError)
+	c := serve(t, s)
+
+	if err := c.Push(1); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if err := c.Push(2); !errors.Is(err, safestack.ErrFull) {
+		t.Fatalf("Push onto a full stack = %v; want ErrFull", err)
+	}
+	s.Close()
+	if _, err := c.Pop(); !errors.Is(err, safestack.ErrClosed) {
+		t.Fatalf("Pop of a closed stack = %v; want ErrClosed", err)
+	}
+}
+
+func TestUnexportedItemType(t *testing.T) {
+	if _, err := NewServer(safestack.NewSafeStack([]job{})); err == nil {
+		t.Fatal("NewServer accepted an unexported item type")
+	}
+	if _, err := NewServer(safestack.NewSafeStack([]*job{})); err == nil {
+		t.Fatal("NewServer accepted a pointer to an unexported item type")
+	}
+}
@@ -0,0 +1,88 @@
+package safestack
+
+// ring - a growable circular buffer; not locked, the types built on it do that
+// front is the oldest end, back the newest.
+type ring[T any] struct {
+	buf  []T
+	head int // index in buf of the front item
+	n    int // # of items
+}
+
+// at - return the item i places behind the front
+func (r *ring[T]) at(i int) T {
+	return r.buf[(r.head+i)%len(r.buf)]
+}
+
+// set - replace the item i places behind the front
+func (r *ring[T]) set(i int, v T) {
+	r.buf[(r.head+i)%len(r.buf)] = v
+}
+
+// grow - make room for one more item, doubling the buffer if it is full
+func (r *ring[T]) grow() {
+	if r.n < len(r.buf) {
+		return
+	}
+	buf := make([]T, max(2*len(r.buf), 8))
+	r.copyTo(buf)
+	r.buf, r.head = buf, 0
+}
+
+// copyTo - copy the items, front first, into dst, which must be long enough
+func (r *ring[T]) copyTo(dst []T) {
+	if r.n == 0 {
+		return
+	}
+	end := r.head + r.n
+	if end <= len(r.buf) {
+		copy(dst, r.buf[r.head:end])
+		return
+	}
+	k := copy(dst, r.buf[r.head:])
+	copy(dst[k:], r.buf[:end-len(r.buf)])
+}
+
+// slice - return a copy of the items, front first
+func (r *ring[T]) slice() []T {
+	s := make([]T, r.n)
+	r.copyTo(s)
+	return s
+}
+
+func (r *ring[T]) pushBack(v T) {
+	r.grow()
+	r.buf[(r.head+r.n)%len(r.buf)] = v
+	r.n++
+}
+
+func (r *ring[T]) pushFront(v T) {
+	r.grow()
+	r.head = (r.head - 1 + len(r.buf)) % len(r.buf)
+	r.buf[r.head] = v
+	r.n++
+}
+
+// popBack - remove and return the back item; the ring must not be empty. the slot is zeroed.
+func (r *ring[T]) popBack() T {
+	i := (r.head + r.n - 1) % len(r.buf)
+	v := r.buf[i]
+	var zero T
+	r.buf[i] = zero
+	r.n--
+	return v
+}
+
+// popFront - remove and return the front item; the ring must not be empty. the slot is zeroed.
+func (r *ring[T]) popFront() T {
+	v := r.buf[r.head]
+	var zero T
+	r.buf[r.head] = zero
+	r.head = (r.head + 1) % len(r.buf)
+	r.n--
+	return v
+}
+
+// reset - drop every item and the buffer
+func (r *ring[T]) reset() {
+	*r = ring[T]{}
+}
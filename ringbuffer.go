@@ -0,0 +1,60 @@
+package safestack
+
+import "sync"
+
+// SafeRingBuffer - a fixed-size circular buffer locked with a mutex; once full, each push overwrites the oldest item.
+// unlike a SafeStack with a Maxsize it never reallocates: the buffer is allocated once, up front.
+type SafeRingBuffer[T any] struct {
+	r     ring[T]
+	mutex sync.RWMutex
+}
+
+// NewSafeRingBuffer - the factory function; return a *SafeRingBuffer[T] that holds up to capacity items (at least one)
+func NewSafeRingBuffer[T any](capacity int) *SafeRingBuffer[T] {
+	return &SafeRingBuffer[T]{r: ring[T]{buf: make([]T, max(capacity, 1))}}
+}
+
+// Push - add an item, overwriting the oldest one if the buffer is full.
+func (b *SafeRingBuffer[T]) Push(item T) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.r.n == len(b.r.buf) {
+		b.r.popFront()
+	}
+	b.r.pushBack(item)
+}
+
+// Latest - return up to n of the most recent items, newest first.
+// Latest(2) after Push(1), Push(2), Push(3) -> [3, 2]
+func (b *SafeRingBuffer[T]) Latest(n int) []T {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	n = min(max(n, 0), b.r.n)
+	latest := make([]T, n)
+	for i := range latest {
+		latest[i] = b.r.at(b.r.n - 1 - i)
+	}
+	return latest
+}
+
+// Len - return the # of items in the buffer.
+func (b *SafeRingBuffer[T]) Len() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.r.n
+}
+
+// Cap - return the # of items the buffer can hold.
+func (b *SafeRingBuffer[T]) Cap() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return len(b.r.buf)
+}
+
+// Clear - empty the buffer but keep its storage.
+func (b *SafeRingBuffer[T]) Clear() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	clear(b.r.buf)
+	b.r.head, b.r.n = 0, 0
+}
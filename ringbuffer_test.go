@@ -0,0 +1,51 @@
+package safestack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingBufferWraps(t *testing.T) {
+	b := NewSafeRingBuffer[int](3)
+	if b.Cap() != 3 || b.Len() != 0 || len(b.Latest(5)) != 0 {
+		t.Fatalf("new buffer: Cap() %d, Len() %d, Latest(5) %v; want 3, 0, []", b.Cap(), b.Len(), b.Latest(5))
+	}
+	b.Push(1)
+	b.Push(2)
+	if got := b.Latest(5); !slices.Equal(got, []int{2, 1}) {
+		t.Fatalf("Latest(5) of a part-full buffer = %v; want [2 1]", got)
+	}
+
+	// ten pushes go three times round the buffer
+	for i := 3; i <= 10; i++ {
+		b.Push(i)
+		want := []int{i, i - 1, i - 2}
+		if got := b.Latest(3); !slices.Equal(got, want) {
+			t.Fatalf("after Push(%d), Latest(3) = %v; want %v", i, got, want)
+		}
+	}
+	if b.Len() != 3 || b.Cap() != 3 {
+		t.Fatalf("Len() %d, Cap() %d after wrapping; want 3, 3", b.Len(), b.Cap())
+	}
+	if got := b.Latest(1); !slices.Equal(got, []int{10}) {
+		t.Fatalf("Latest(1) = %v; want [10]", got)
+	}
+	if got := b.Latest(-1); len(got) != 0 {
+		t.Fatalf("Latest(-1) = %v; want []", got)
+	}
+
+	b.Clear()
+	b.Push(11)
+	if got := b.Latest(3); !slices.Equal(got, []int{11}) || b.Len() != 1 {
+		t.Fatalf("after Clear() and Push(11), Latest(3) = %v with Len() %d; want [11] and 1", got, b.Len())
+	}
+}
+
+func TestRingBufferMinCapacity(t *testing.T) {
+	b := NewSafeRingBuffer[string](0)
+	b.Push("a")
+	b.Push("b")
+	if got := b.Latest(2); b.Cap() != 1 || !slices.Equal(got, []string{"b"}) {
+		t.Fatalf("Cap() %d, Latest(2) %v; want 1 and [b]", b.Cap(), got)
+	}
+}
@@ -0,0 +1,122 @@
+package safestack
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SafeDeque - a double-ended stack locked with a mutex: O(1) pushes and pops at both the top and the bottom.
+// it sits on a ring buffer, so consuming from the bottom never shifts or leaks the backing array.
+type SafeDeque[T any] struct {
+	r       ring[T]
+	mutex   sync.RWMutex
+	maxsize int
+}
+
+// NewSafeDeque - the factory function; return a *SafeDeque[T] holding a copy of items, items[len(items)-1] on top
+func NewSafeDeque[T any](items []T) *SafeDeque[T] {
+	d := &SafeDeque[T]{}
+	for _, item := range items {
+		d.r.pushBack(item)
+	}
+	return d
+}
+
+// NewMax - set a new max deque size; trim to that size if necessary by dropping from the bottom; NewMax(0) lifts the limit.
+func (d *SafeDeque[T]) NewMax(n int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.maxsize = n
+	for n > 0 && d.r.n > n {
+		d.r.popFront()
+	}
+}
+
+// Push - add an item to the top; when full drop an item from the bottom: a sliding window.
+// Push(3) onto [1, 2] -> deque [1, 2, 3]
+func (d *SafeDeque[T]) Push(item T) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.r.pushBack(item)
+	if d.maxsize > 0 && d.r.n > d.maxsize {
+		d.r.popFront()
+	}
+}
+
+// PushBottom - add an item to the bottom; when full drop an item from the top.
+// PushBottom(0) onto [1, 2] -> deque [0, 1, 2]
+func (d *SafeDeque[T]) PushBottom(item T) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.r.pushFront(item)
+	if d.maxsize > 0 && d.r.n > d.maxsize {
+		d.r.popBack()
+	}
+}
+
+// Pop - take the top item.
+// Pop() from deque [1, 2, 3] -> return 3; and now deque is [1, 2]
+func (d *SafeDeque[T]) Pop() (T, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	var i T
+	if d.r.n == 0 {
+		return i, fmt.Errorf("empty deque")
+	}
+	return d.r.popBack(), nil
+}
+
+// PopBottom - take the bottom item.
+// PopBottom() from deque [1, 2, 3] -> return 1; and now deque is [2, 3]
+func (d *SafeDeque[T]) PopBottom() (T, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	var i T
+	if d.r.n == 0 {
+		return i, fmt.Errorf("empty deque")
+	}
+	return d.r.popFront(), nil
+}
+
+// Peek - look at the top item; but do not pop it.
+func (d *SafeDeque[T]) Peek() (T, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	var i T
+	if d.r.n == 0 {
+		return i, fmt.Errorf("empty deque")
+	}
+	return d.r.at(d.r.n - 1), nil
+}
+
+// PeekBottom - look at the bottom item; but do not pop it.
+func (d *SafeDeque[T]) PeekBottom() (T, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	var i T
+	if d.r.n == 0 {
+		return i, fmt.Errorf("empty deque")
+	}
+	return d.r.at(0), nil
+}
+
+// Len - return the # of items in the deque.
+func (d *SafeDeque[T]) Len() int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.r.n
+}
+
+// PeekAtSlice - return a copy of all items, bottom first.
+func (d *SafeDeque[T]) PeekAtSlice() []T {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.r.slice()
+}
+
+// Clear - empty the deque.
+func (d *SafeDeque[T]) Clear() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.r.reset()
+}
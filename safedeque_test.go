@@ -0,0 +1,103 @@
+package safestack
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestDequeEnds(t *testing.T) {
+	d := NewSafeDeque([]int{1, 2})
+	d.Push(3)
+	d.PushBottom(0)
+	if got := d.PeekAtSlice(); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Fatalf("PeekAtSlice() = %v; want [0 1 2 3]", got)
+	}
+	if top, err := d.Peek(); err != nil || top != 3 {
+		t.Fatalf("Peek() = %d, %v; want 3", top, err)
+	}
+	if bottom, err := d.PeekBottom(); err != nil || bottom != 0 {
+		t.Fatalf("PeekBottom() = %d, %v; want 0", bottom, err)
+	}
+	if top, err := d.Pop(); err != nil || top != 3 {
+		t.Fatalf("Pop() = %d, %v; want 3", top, err)
+	}
+	if bottom, err := d.PopBottom(); err != nil || bottom != 0 {
+		t.Fatalf("PopBottom() = %d, %v; want 0", bottom, err)
+	}
+	d.Clear()
+	if _, err := d.Pop(); err == nil {
+		t.Fatal("Pop() of a cleared deque succeeded")
+	}
+	if _, err := d.PopBottom(); err == nil {
+		t.Fatal("PopBottom() of a cleared deque succeeded")
+	}
+}
+
+func TestDequeMaxsize(t *testing.T) {
+	d := NewSafeDeque([]int{1, 2, 3, 4})
+	d.NewMax(3)
+	if got := d.PeekAtSlice(); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Fatalf("after NewMax(3), PeekAtSlice() = %v; want [2 3 4]", got)
+	}
+
+	d.Push(5)
+	if got := d.PeekAtSlice(); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Fatalf("Push() at Maxsize left %v; want [3 4 5], dropped from the bottom", got)
+	}
+	d.PushBottom(2)
+	if got := d.PeekAtSlice(); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Fatalf("PushBottom() at Maxsize left %v; want [2 3 4], dropped from the top", got)
+	}
+
+	d.NewMax(0)
+	d.Push(5)
+	d.PushBottom(1)
+	if d.Len() != 5 {
+		t.Fatalf("Len() = %d after NewMax(0) lifted the limit; want 5", d.Len())
+	}
+}
+
+// TestDequeModel - random operations at both ends against a plain slice, wrapping the ring many times over
+func TestDequeModel(t *testing.T) {
+	d := NewSafeDeque([]int{})
+	var model []int
+	rng := rand.New(rand.NewPCG(262, 262))
+	for i := range 5000 {
+		switch rng.IntN(4) {
+		case 0:
+			d.Push(i)
+			model = append(model, i)
+		case 1:
+			d.PushBottom(i)
+			model = slices.Insert(model, 0, i)
+		case 2:
+			got, err := d.Pop()
+			if len(model) == 0 {
+				if err == nil {
+					t.Fatalf("op %d: Pop() of an empty deque succeeded", i)
+				}
+				continue
+			}
+			if err != nil || got != model[len(model)-1] {
+				t.Fatalf("op %d: Pop() = %d, %v; want %d", i, got, err, model[len(model)-1])
+			}
+			model = model[:len(model)-1]
+		case 3:
+			got, err := d.PopBottom()
+			if len(model) == 0 {
+				if err == nil {
+					t.Fatalf("op %d: PopBottom() of an empty deque succeeded", i)
+				}
+				continue
+			}
+			if err != nil || got != model[0] {
+				t.Fatalf("op %d: PopBottom() = %d, %v; want %d", i, got, err, model[0])
+			}
+			model = model[1:]
+		}
+		if got := d.PeekAtSlice(); !slices.Equal(got, model) {
+			t.Fatalf("op %d: PeekAtSlice() = %v; want %v", i, got, model)
+		}
+	}
+}
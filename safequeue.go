@@ -0,0 +1,116 @@
+package safestack
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SafeQueue - the first in first out companion of SafeStack: a queue locked with a mutex
+type SafeQueue[T any] struct {
+	Items   []T
+	mutex   sync.RWMutex
+	Maxsize int
+}
+
+// NewSafeQueue - the factory function; return a *SafeQueue[T] whose front is items[0]
+func NewSafeQueue[T any](items []T) *SafeQueue[T] {
+	return &SafeQueue[T]{
+		Items:   items,
+		mutex:   sync.RWMutex{},
+		Maxsize: 0,
+	}
+}
+
+// NewMax - set a new max queue size; trim to that size if necessary; drop the oldest items first.
+// NewMax(2) on queue [1, 2, 3] -> [2, 3]; NewMax(0) lifts the limit
+func (q *SafeQueue[T]) NewMax(n int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.Maxsize = n
+	if n > 0 {
+		q.trim(n)
+	}
+}
+
+// Trim - drop the queue size down to n; drop the oldest items first.
+// Trim(2) on queue [1, 2, 3] -> [2, 3]
+func (q *SafeQueue[T]) Trim(n int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.trim(max(n, 0))
+}
+
+// trim - the body of Trim(); the caller holds the write lock
+func (q *SafeQueue[T]) trim(n int) {
+	if n < len(q.Items) {
+		kept := make([]T, n)
+		copy(kept, q.Items[len(q.Items)-n:])
+		q.Items = kept
+	}
+}
+
+// Enqueue - add an item to the back of the queue; drop an item from the front if necessary.
+// Enqueue(3) onto [1, 2] -> queue [1, 2, 3]
+func (q *SafeQueue[T]) Enqueue(item T) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.Items = append(q.Items, item)
+	if q.Maxsize > 0 && len(q.Items) > q.Maxsize {
+		n := len(q.Items) - q.Maxsize
+		clear(q.Items[:n])
+		q.Items = q.Items[n:]
+	}
+}
+
+// Dequeue - take the front item from the queue leaving it smaller by one.
+// Dequeue() from queue [1, 2, 3] -> return 1; and now queue is [2, 3].
+func (q *SafeQueue[T]) Dequeue() (T, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var i T
+	if len(q.Items) == 0 {
+		return i, fmt.Errorf("empty queue")
+	}
+
+	i = q.Items[0]
+	clear(q.Items[:1])
+	q.Items = q.Items[1:]
+	return i, nil
+}
+
+// PeekFront - look at the front item in the queue; but do not dequeue it.
+// PeekFront() from queue [1, 2, 3] -> return 1; and queue is still [1, 2, 3]
+func (q *SafeQueue[T]) PeekFront() (T, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var i T
+	if len(q.Items) == 0 {
+		return i, fmt.Errorf("empty queue")
+	}
+	return q.Items[0], nil
+}
+
+// Len - return the # of items in the queue.
+func (q *SafeQueue[T]) Len() int {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	return len(q.Items)
+}
+
+// PeekAll - return all items in the queue, front first, but leave the queue unchanged.
+func (q *SafeQueue[T]) PeekAll() []T {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	all := make([]T, len(q.Items))
+	copy(all, q.Items)
+	return all
+}
+
+// Clear - empty the queue.
+func (q *SafeQueue[T]) Clear() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.Items = []T{}
+}
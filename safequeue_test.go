@@ -0,0 +1,22 @@
+package safestack
+
+import "testing"
+
+func TestSafeQueueTrim(t *testing.T) {
+	for _, tc := range []struct {
+		n    int
+		want int
+	}{{-1, 0}, {0, 0}, {2, 2}, {5, 3}} {
+		q := NewSafeQueue([]int{1, 2, 3})
+		q.Trim(tc.n)
+		if q.Len() != tc.want {
+			t.Errorf("Trim(%d) on [1, 2, 3] leaves %d items; want %d", tc.n, q.Len(), tc.want)
+		}
+	}
+
+	q := NewSafeQueue([]int{1, 2, 3})
+	q.Trim(2)
+	if i, err := q.Dequeue(); err != nil || i != 2 {
+		t.Fatalf("Dequeue() after Trim(2) = %d, %v; want 2", i, err)
+	}
+}
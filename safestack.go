@@ -1,17 +1,54 @@
 package safestack
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type SafeStack[T any] struct {
-	Items   []T
-	mutex   sync.RWMutex
-	Maxsize int
+	Items     []T
+	mutex     sync.RWMutex
+	Maxsize   int
+	journal   journal[T]
+	sizeof    func(T) int
+	maxWeight int
+	onEvict   func(T)
+	evicted   []T
+	onEmpty   EmptyBehavior
+	wake      chan struct{}
+	highest   int
+	counts    opCounts
+	grow      autoGrow
+	overflow  OverflowPolicy
+	closed    bool
+	done      chan struct{}
+	subs      map[*subscriber[T]]bool
+	tracer    Tracer[T]
+	hooks     lifecycleHooks[T]
+	shrink    bool
+	cow       bool
+	text      TextCodec[T]
+	limiter   Limiter
+	sampler   *sampler
+	stale     bool
+	published atomic.Pointer[[]T]
+	version   atomic.Uint64
+	heft      int
+	weighedAt uint64
+	pushedAt  []time.Time
+	stampedAt uint64
+	traces    []traceEvent[T]
+
+	checkpoints    []checkpoint[T]
+	lastCheckpoint CheckpointID
 }
 
 // NewSafeStack - the factory function; return a *SafeStack[T]
+// the stack adopts items as its storage; use NewSafeStackSafe() if the caller will keep using the slice
 func NewSafeStack[T any](items []T) *SafeStack[T] {
 	return &SafeStack[T]{
 		Items:   items,
@@ -20,67 +57,274 @@ func NewSafeStack[T any](items []T) *SafeStack[T] {
 	}
 }
 
+// NewSafeStackSafe - NewSafeStack() but on a copy of items, so that the caller's slice and the stack share no memory
+func NewSafeStackSafe[T any](items []T) *SafeStack[T] {
+	c := make([]T, len(items))
+	copy(c, items)
+	return NewSafeStack(c)
+}
+
+// NewSafeStackCap - the factory function for an empty stack with room for n items before its storage needs to grow
+func NewSafeStackCap[T any](n int) *SafeStack[T] {
+	return NewSafeStack(make([]T, 0, max(n, 0)))
+}
+
+// Grow - make room for at least n more items, so that the next n pushes do not reallocate the stack's storage.
+// this is at odds with SetAutoShrink(true), which may hand the room straight back.
+func (s *SafeStack[T]) Grow(n int) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.Items = slices.Grow(s.Items, max(n, 0))
+}
+
 // NewMax - set a new max stack size; trim to that size if necessary; drop the deepest items first.
-// NewMax(2) on stack [1, 2, 3] -> [2, 3]
+// NewMax(2) on stack [1, 2, 3] -> [2, 3]; NewMax(0) lifts the limit
+// the new limit and the trim take effect in one step, so no push can slip in between them.
 func (s *SafeStack[T]) NewMax(n int) {
-	s.Trim(n)
 	s.mutex.Lock()
+	defer s.unlock()
+	if n > 0 {
+		s.trim(n)
+	}
 	s.Maxsize = n
-	s.mutex.Unlock()
+	s.journal.op(opMax, n)
+	s.changedKeeping()
 }
 
 // Trim - drop the stack size down to n; drop the deepest items first.
 // Trim(2) on stack [1, 2, 3] -> [2, 3]
 func (s *SafeStack[T]) Trim(n int) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
+	s.trim(max(n, 0))
+}
+
+// trim - the body of Trim(); the caller holds the write lock
+// the kept items move to a fresh array: re-slicing would leave the dropped ones reachable and uncollectable.
+func (s *SafeStack[T]) trim(n int) {
 	if n < len(s.Items) {
-		s.Items = s.Items[len(s.Items)-n : len(s.Items)]
+		if s.stamped() {
+			s.pushedAt = slices.Clone(s.pushedAt[len(s.pushedAt)-n:])
+		}
+		s.evict(s.Items[:len(s.Items)-n]...)
+		kept := make([]T, n)
+		copy(kept, s.Items[len(s.Items)-n:])
+		s.Items = kept
+		s.journal.op(opTrim, n)
+		s.changedKeeping()
 	}
 }
 
-// RePopulate - insert a new slice into the stack; drop down to maxsize if necessary.
-// note that here the item order is the inverse of Clear() + PushMany(): FILO vs LIFO
-func (s *SafeStack[T]) RePopulate(items []T) {
+// Order - how a slice handed to RePopulate() maps onto the stack
+type Order int
+
+const (
+	BottomFirst Order = iota // items[0] is the bottom and the last item the top: the order of PushMany() and Snapshot()
+	TopFirst                 // items[0] is the top: the order of PeekAll() and DrainLIFO()
+)
+
+// RePopulate - replace the contents of the stack with a slice in one step; drop down to maxsize (if any) if necessary.
+// by default the last item of the slice becomes the top of the stack, just as with Clear() + PushMany(); pass TopFirst
+// for a slice in the order PeekAll() returns. by default the slice itself becomes the stack's storage, so mutating it
+// afterwards mutates the stack; with TopFirst the stack reverses a copy and leaves the slice alone. see RePopulateLIFO().
+// RePopulate([1, 2, 3]) -> stack [1, 2, 3]; Pop() returns 3
+// RePopulate([1, 2, 3], TopFirst) -> stack [3, 2, 1]; Pop() returns 1
+func (s *SafeStack[T]) RePopulate(items []T, order ...Order) {
 	s.mutex.Lock()
+	defer s.unlock()
+	if s.closed {
+		return
+	}
+	if len(order) > 0 && order[0] == TopFirst {
+		items = slices.Clone(items)
+		slices.Reverse(items)
+	}
 	s.Items = items
-	s.mutex.Unlock()
-	s.Trim(s.Maxsize)
+	s.journal.reset(s.Items)
+	s.changed()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
 }
 
-// Push - add an item to the top of the stack; drop an item from the bottom if necessary.
+// RePopulateLIFO - RePopulate() with a copy of the slice; the result is exactly that of Clear() + PushMany(), but atomic.
+// RePopulateLIFO([1, 2, 3]) -> stack [1, 2, 3]; Pop() returns 3
+func (s *SafeStack[T]) RePopulateLIFO(items []T) {
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.closed {
+		return
+	}
+	s.Items = make([]T, len(items))
+	copy(s.Items, items)
+	s.journal.reset(s.Items)
+	s.changed()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
+}
+
+// Push - add an item to the top of the stack; if the stack is full, do what the overflow policy says:
+// by default drop an item from the bottom.
 // Push(3) onto [1, 2] -> stack [1, 2, 3]
 func (s *SafeStack[T]) Push(item T) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.Items = append(s.Items, item)
-	if s.Maxsize != 0 && len(s.Items) > s.Maxsize {
-		s.Items = s.Items[1:]
+	defer s.unlock()
+	_ = s.push(item)
+}
+
+// push - the body of Push(); the caller holds the write lock
+// returns ErrFull if the overflow policy refused the item and ErrClosed on a closed stack.
+func (s *SafeStack[T]) push(item T) error {
+	return s.insert(0, item)
+}
+
+// insert - the body of push() and InsertAt(): add item i places below the top, where 0 <= i <= len(s.Items), with all
+// the bookkeeping of a push; the caller holds the write lock. errors as for push().
+func (s *SafeStack[T]) insert(i int, item T) error {
+	var sizeof func(T) int
+	if s.maxWeight > 0 {
+		if sizeof = s.weigher(); sizeof != nil {
+			s.weight(sizeof)
+		}
+	}
+	stamping := s.stamp()
+	if err := s.makeRoom(); err != nil {
+		return err
+	}
+	// DropNewest may have taken the old top away first
+	k := max(len(s.Items)-i, 0)
+	s.Items = slices.Insert(s.Items, k, item)
+	if s.weighed() {
+		s.heft += sizeof(item)
+	}
+	if stamping {
+		s.pushedAt = slices.Insert(s.pushedAt[:len(s.Items)-1], k, time.Now())
+	}
+	s.counts.pushes++
+	s.trace(traceEvent[T]{kind: opPush, item: item})
+	if k == len(s.Items)-1 {
+		s.journal.push(item)
+	} else {
+		s.journal.reset(s.Items)
+	}
+	for sub := range s.subs {
+		sub.notify(item)
+	}
+	if s.Maxsize > 0 && len(s.Items) > s.Maxsize {
+		n := len(s.Items) - s.Maxsize
+		s.evict(s.Items[:n]...)
+		clear(s.Items[:n])
+		s.Items = s.Items[n:]
+		s.journal.op(opTrim, len(s.Items))
+	}
+	if s.maxWeight > 0 {
+		s.evictToBytes(s.maxWeight)
+	}
+	weighed := s.weighed()
+	if stamping {
+		// and Maxsize or the weight budget may have taken items from the bottom since
+		s.pushedAt = s.pushedAt[len(s.pushedAt)-len(s.Items):]
+	}
+	s.changed()
+	if weighed {
+		s.markWeighed()
+	}
+	if stamping {
+		s.markStamped()
 	}
+	return nil
 }
 
-// PushMany - add multiple items to the top of the stack; first in last out.
+// makeRoom - grow the stack or apply the overflow policy if it is full; DropOldest is left to the caller,
+// which trims once the new item is in. the caller holds the write lock.
+func (s *SafeStack[T]) makeRoom() error {
+	if s.closed {
+		return ErrClosed
+	}
+	if s.Maxsize > 0 && len(s.Items) >= s.Maxsize && s.grow.apply(&s.Maxsize, len(s.Items)+1) {
+		s.journal.op(opMax, s.Maxsize)
+	}
+	if s.Maxsize > 0 && len(s.Items) >= s.Maxsize {
+		switch s.overflow {
+		case Reject, ReturnError:
+			return ErrFull
+		case DropNewest:
+			s.evict(s.Items[len(s.Items)-1])
+			clear(s.Items[len(s.Items)-1:])
+			s.Items = s.Items[:len(s.Items)-1]
+			s.journal.op(opPop, 0)
+		}
+	}
+	return nil
+}
+
+// PushMany - add multiple items to the top of the stack in one step; first in last out.
+// no other goroutine can see or pop a partly pushed batch; Maxsize and the overflow policy apply item by item.
 // PushMany([1, 2, 3]) onto stack [-1, 0] -> stack [-1, 0, 1, 2, 3]
 func (s *SafeStack[T]) PushMany(items []T) {
+	s.mutex.Lock()
+	defer s.unlock()
 	for _, item := range items {
-		s.Push(item)
+		_ = s.push(item)
+	}
+}
+
+// PushUniqueFunc - push item unless eq reports it equal to an item already in the stack; check and push are one step.
+// returns whether item was pushed. PushUniqueFunc(3, sameID) onto stack [1, 2] -> true; and now stack is [1, 2, 3]
+func (s *SafeStack[T]) PushUniqueFunc(item T, eq func(a, b T) bool) bool {
+	s.mutex.Lock()
+	defer s.unlock()
+	for _, held := range s.Items {
+		if eq(held, item) {
+			return false
+		}
 	}
+	return s.push(item) == nil
 }
 
 // Len - return the # of items in the stack.
 func (s *SafeStack[T]) Len() int {
+	if items, ok := s.cowItems(); ok {
+		return len(items)
+	}
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 	return len(s.Items)
 }
 
+// StackInfo - a consistent snapshot of the state of a stack; see Info()
+type StackInfo struct {
+	Len            int
+	Cap            int
+	Maxsize        int
+	OverflowPolicy OverflowPolicy
+	Hooks          bool // whether an OnEvict, OnPush, OnPop, or OnClear hook, or a Tracer, is registered
+}
+
+// Info - return the length, capacity, and configuration of the stack as of a single moment.
+func (s *SafeStack[T]) Info() StackInfo {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return StackInfo{
+		Len:            len(s.Items),
+		Cap:            cap(s.Items),
+		Maxsize:        s.Maxsize,
+		OverflowPolicy: s.overflow,
+		Hooks:          s.onEvict != nil || s.tracer != nil || s.hooks.set(),
+	}
+}
+
 // Peek - look at the top item in the stack; but do not pop it.
 // peek() from stack [1, 2, 3] -> return 3; and stack is still [1, 2, 3]
 func (s *SafeStack[T]) Peek() (T, error) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
 
 	var i T
+	if s.closed {
+		return i, ErrClosed
+	}
 	if len(s.Items) == 0 {
 		return i, fmt.Errorf("empty stack")
 	}
@@ -89,16 +333,169 @@ func (s *SafeStack[T]) Peek() (T, error) {
 	return i, nil
 }
 
+// PeekCopy - Peek() but return a copy of the top item that shares nothing with the stack's storage; false if empty.
+// items that implement Cloner[T] are deep-copied, so mutating the copy cannot reach the item still on the stack.
+// PeekCopy() from stack [1, 2, 3] -> return 3, true; and stack is still [1, 2, 3]
+func (s *SafeStack[T]) PeekCopy() (T, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var i T
+	if len(s.Items) == 0 {
+		return i, false
+	}
+
+	i = cloneItem(s.Items[len(s.Items)-1])
+	return i, true
+}
+
 // Pop - pop the top item from the stack leaving it smaller by one.
 // Pop() from stack [1, 2, 3] -> return 3; and now stack is [1, 2].
+// what happens on an empty stack is up to SetEmptyBehavior(): by default an error.
 func (s *SafeStack[T]) Pop() (T, error) {
-	i, e := s.Peek()
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.onEmpty == EmptyBlock {
+		_ = s.await(context.Background(), s.nonEmpty)
+	}
+
+	if len(s.Items) == 0 && s.onEmpty == EmptyZero && !s.closed {
+		var i T
+		return i, nil
+	}
+	return s.pop()
+}
+
+// PopLast - Pop() but also report whether that emptied the stack.
+// PopLast() from stack [1, 2] -> return 2, false; PopLast() again -> return 1, true
+func (s *SafeStack[T]) PopLast() (item T, wasLast bool, err error) {
+	s.mutex.Lock()
+	defer s.unlock()
+	item, err = s.pop()
+	return item, err == nil && len(s.Items) == 0, err
+}
+
+// pop - the body of Pop(); the caller holds the write lock
+// the vacated slot is zeroed so that the backing array does not keep the popped item alive.
+func (s *SafeStack[T]) pop() (T, error) {
+	var i T
+	if s.closed {
+		return i, ErrClosed
+	}
 	if len(s.Items) == 0 {
-		return i, e
-	} else {
-		s.Items = s.Items[:len(s.Items)-1]
-		return i, e
+		return i, fmt.Errorf("empty stack")
+	}
+
+	i = s.Items[len(s.Items)-1]
+	stamping, queued := s.stamped(), s.queued(len(s.Items)-1)
+	clear(s.Items[len(s.Items)-1:])
+	s.Items = s.Items[:len(s.Items)-1]
+	weighed := s.weighed()
+	if weighed {
+		s.heft -= s.weigher()(i)
+	}
+	if stamping {
+		s.pushedAt = s.pushedAt[:len(s.Items)]
+	}
+	s.counts.pops++
+	s.trace(traceEvent[T]{kind: opPop, item: i, d: queued})
+	s.journal.op(opPop, 0)
+	s.changed()
+	if weighed {
+		s.markWeighed()
+	}
+	if stamping {
+		s.markStamped()
+	}
+	return i, nil
+}
+
+// PopN - pop up to n items from the top in one step; return them top first.
+// PopN(2) from stack [1, 2, 3] -> return [3, 2]; and now stack is [1]
+func (s *SafeStack[T]) PopN(n int) []T {
+	s.mutex.Lock()
+	defer s.unlock()
+	n = min(max(n, 0), len(s.Items))
+	popped := make([]T, 0, n)
+	for range n {
+		i, err := s.pop()
+		if err != nil {
+			break
+		}
+		popped = append(popped, i)
+	}
+	return popped
+}
+
+// PeekN - look at up to n items from the top, top first; but do not pop them.
+// PeekN(2) from stack [1, 2, 3] -> return [3, 2]; and stack is still [1, 2, 3]
+func (s *SafeStack[T]) PeekN(n int) []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	n = min(max(n, 0), len(s.Items))
+	peeked := make([]T, n)
+	for i := range peeked {
+		peeked[i] = s.Items[len(s.Items)-1-i]
+	}
+	return peeked
+}
+
+// TryPop - Pop() but report an empty stack with false instead of an error; never blocks.
+// TryPop() from stack [1, 2, 3] -> return 3, true; TryPop() from stack [] -> return 0, false
+func (s *SafeStack[T]) TryPop() (T, bool) {
+	s.mutex.Lock()
+	defer s.unlock()
+	i, err := s.pop()
+	return i, err == nil
+}
+
+// PopIf - pop the top item only if it satisfies pred; check and pop are one step. reports whether an item was popped.
+// PopIf(isOdd) from stack [1, 2, 3] -> return 3, true; PopIf(isEven) from stack [1, 2, 3] -> return 0, false
+func (s *SafeStack[T]) PopIf(pred func(T) bool) (T, bool) {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	var i T
+	if s.closed || len(s.Items) == 0 || !pred(s.Items[len(s.Items)-1]) {
+		return i, false
+	}
+	i, err := s.pop()
+	return i, err == nil
+}
+
+// TryPush - Push() unless another goroutine holds the lock, in which case give up at once rather than queue behind it;
+// report whether the item was pushed. a refusal by the overflow policy also reports false.
+func (s *SafeStack[T]) TryPush(item T) bool {
+	if !s.mutex.TryLock() {
+		return false
+	}
+	defer s.unlock()
+	return s.push(item) == nil
+}
+
+// TryPopUncontended - TryPop() that also gives up at once, rather than queue, if another goroutine holds the lock;
+// for callers that would sooner shed load than wait behind a long PeekAll(), say. TryPop() itself only fails when empty.
+func (s *SafeStack[T]) TryPopUncontended() (T, bool) {
+	if !s.mutex.TryLock() {
+		var i T
+		return i, false
 	}
+	defer s.unlock()
+	i, err := s.pop()
+	return i, err == nil
+}
+
+// TryPeek - Peek() but report an empty stack with false instead of an error.
+// TryPeek() from stack [1, 2, 3] -> return 3, true; and stack is still [1, 2, 3]
+func (s *SafeStack[T]) TryPeek() (T, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var i T
+	if s.closed || len(s.Items) == 0 {
+		return i, false
+	}
+	return s.Items[len(s.Items)-1], true
 }
 
 // AssumeSafePop - Pop() but brazenly assume that the stack is not empty.
@@ -112,55 +509,131 @@ func (s *SafeStack[T]) AssumeSafePop() T {
 // Clear() on stack [1, 2, 3] -> []
 func (s *SafeStack[T]) Clear() {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
+	s.empty()
+}
+
+// empty - the body of Clear(); the caller holds the write lock
+func (s *SafeStack[T]) empty() {
 	s.Items = []T{}
+	s.trace(traceEvent[T]{kind: opClear})
+	s.journal.op(opClear, 0)
+	s.changed()
 }
 
 // PeekAll - return all items in the stack but leave the stack unchanged; last in first out.
 // Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> PeekAll() returns [3, 2, 1]
 func (s *SafeStack[T]) PeekAll() []T {
+	if items, ok := s.cowItems(); ok {
+		all := slices.Clone(items)
+		slices.Reverse(all)
+		return all
+	}
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	li := len(s.Items)
-	all := make([]T, li)
+	all := make([]T, len(s.Items))
+	copy(all, s.Items)
+	slices.Reverse(all)
+	return all
+}
 
-	for i := 0; i < li; i++ {
-		all[(li-1)-i] = s.Items[i]
+// PeekAllAppend - PeekAll() appended to dst: with a dst of ample capacity, reused from call to call, nothing is allocated.
+// the draining counterpart is PopAllInto(). PeekAllAppend(buf[:0]) on stack [1, 2, 3] -> return [3, 2, 1]
+func (s *SafeStack[T]) PeekAllAppend(dst []T) []T {
+	items, ok := s.cowItems()
+	if !ok {
+		s.mutex.RLock()
+		defer s.mutex.RUnlock()
+		items = s.Items
 	}
-	return all
+	for i := len(items) - 1; i >= 0; i-- {
+		dst = append(dst, items[i])
+	}
+	return dst
 }
 
-// PeekAtSlice - return all items in the stack but leave the stack unchanged; first in last out.
+// PeekAtSlice - return a copy of all items in the stack but leave the stack unchanged; first in last out.
 // Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> PeekAtSlice() returns [1, 2, 3]
 func (s *SafeStack[T]) PeekAtSlice() []T {
+	return s.snapshot()
+}
+
+// Snapshot - PeekAtSlice() by another name: a copy of the items in push order, the caller's to modify.
+func (s *SafeStack[T]) Snapshot() []T {
+	return s.snapshot()
+}
+
+// UnsafeSlice - return the stack's own storage, bottom first, without copying it. the slice stays shared with the stack:
+// reading it races with later pushes and pops, and writing to it (sorting it, say) corrupts the stack.
+// only for callers that stop every other user of the stack first; everyone else wants Snapshot().
+func (s *SafeStack[T]) UnsafeSlice() []T {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 	return s.Items
 }
 
-// PopAll - return all items in the stack and empty the stack; last in first out.
+// DrainLIFO - return all items in the stack and empty it in one step; last in first out.
+// Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> DrainLIFO() returns [3, 2, 1]; and now stack is []
+func (s *SafeStack[T]) DrainLIFO() []T {
+	all := s.DrainFIFO()
+	slices.Reverse(all)
+	return all
+}
+
+// DrainFIFO - return all items in the stack and empty it in one step; first in last out.
+// Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> DrainFIFO() returns [1, 2, 3]; and now stack is []
+func (s *SafeStack[T]) DrainFIFO() []T {
+	s.mutex.Lock()
+	defer s.unlock()
+	all := s.Items
+	s.counts.pops += uint64(len(all))
+	for i := len(all) - 1; i >= 0; i-- {
+		s.trace(traceEvent[T]{kind: opPop, item: all[i], d: s.queued(i)})
+	}
+	s.empty()
+	return all
+}
+
+// PopAllInto - DrainLIFO() into buf, reusing its capacity: return buf[:0] with the items appended, last in first out.
+// the stack keeps its own storage too, so a steady cycle of pushes and PopAllInto() does not allocate.
+// PopAllInto(buf) on stack [1, 2, 3] -> return [3, 2, 1]; and now stack is []
+func (s *SafeStack[T]) PopAllInto(buf []T) []T {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	buf = buf[:0]
+	for i := len(s.Items) - 1; i >= 0; i-- {
+		buf = append(buf, s.Items[i])
+		s.trace(traceEvent[T]{kind: opPop, item: s.Items[i], d: s.queued(i)})
+	}
+	s.counts.pops += uint64(len(s.Items))
+	clear(s.Items)
+	s.Items = s.Items[:0]
+	s.trace(traceEvent[T]{kind: opClear})
+	s.journal.op(opClear, 0)
+	s.changed()
+	return buf
+}
+
+// PopAll - DrainLIFO() by its older name.
 // Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> PopAll() returns [3, 2, 1]
 func (s *SafeStack[T]) PopAll() []T {
-	all := s.PeekAll()
-	s.Clear()
-	return all
+	return s.DrainLIFO()
 }
 
-// PopSlice - return all items in the stack and empty the stack; first in last out.
+// PopSlice - DrainFIFO() by its older name.
 // Push(1), Push(2), Push(3) -> stack [1, 2, 3] -> PopSlice() returns [1, 2, 3]
 func (s *SafeStack[T]) PopSlice() []T {
-	all := s.PeekAtSlice()
-	s.Clear()
-	return all
+	return s.DrainFIFO()
 }
 
-// Reverse - invert the item order in the stack.
+// Reverse - invert the item order in the stack, in place and in one step.
 // Reverse() stack [1, 2, 3] -> stack [3, 2, 1]
 func (s *SafeStack[T]) Reverse() {
-	// PeekAll() reverses... Just need to write after the read.
-	rev := s.PeekAll()
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-	s.Items = rev
+	s.mutex.Lock()
+	defer s.unlock()
+	slices.Reverse(s.Items)
+	s.journal.reset(s.Items)
+	s.changed()
 }
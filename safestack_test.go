@@ -0,0 +1,467 @@
+package safestack
+
+import (
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// record - an item that points to storage of its own and knows how to deep-copy itself
+type record struct {
+	id   int
+	tags []string
+}
+
+func (r record) Clone() record {
+	return record{id: r.id, tags: slices.Clone(r.tags)}
+}
+
+func TestPeekCopyIndependent(t *testing.T) {
+	s := NewSafeStack([]record{{id: 1, tags: []string{"a"}}, {id: 2, tags: []string{"b", "c"}}})
+	c, ok := s.PeekCopy()
+	if !ok || c.id != 2 || !slices.Equal(c.tags, []string{"b", "c"}) {
+		t.Fatalf("PeekCopy() = %v, %v; want {2 [b c]}, true", c, ok)
+	}
+
+	s.Do(func(items []record) []record {
+		items[len(items)-1].tags[0] = "x"
+		items[len(items)-1].id = 9
+		return items
+	})
+	_, _ = s.Pop()
+	s.Push(record{id: 3})
+	if c.id != 2 || !slices.Equal(c.tags, []string{"b", "c"}) {
+		t.Fatalf("the copy changed along with the stack: %v", c)
+	}
+
+	c.tags[0] = "y"
+	if top, _ := s.Peek(); top.id != 3 || top.tags != nil {
+		t.Fatalf("changing the copy reached the stack: top is %v", top)
+	}
+}
+
+func TestPeekCopyEmpty(t *testing.T) {
+	if _, ok := NewSafeStack([]int{}).PeekCopy(); ok {
+		t.Fatal("PeekCopy() of an empty stack reported an item")
+	}
+}
+
+func TestPopLast(t *testing.T) {
+	s := NewSafeStack([]int{1, 2})
+	if i, last, err := s.PopLast(); err != nil || i != 2 || last {
+		t.Fatalf("PopLast() of [1, 2] = %d, %v, %v; want 2, false, nil", i, last, err)
+	}
+	if i, last, err := s.PopLast(); err != nil || i != 1 || !last {
+		t.Fatalf("PopLast() of [1] = %d, %v, %v; want 1, true, nil", i, last, err)
+	}
+	if _, last, err := s.PopLast(); err == nil || last {
+		t.Fatalf("PopLast() of [] = _, %v, %v; want false and an error", last, err)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	s := NewSafeStackCap[int](8)
+	s.PushMany([]int{1, 2, 3})
+	s.NewMax(5)
+	s.SetOverflowPolicy(Reject)
+	want := StackInfo{Len: 3, Cap: 8, Maxsize: 5, OverflowPolicy: Reject}
+	if got := s.Info(); got != want {
+		t.Fatalf("Info() = %+v; want %+v", got, want)
+	}
+
+	s.OnEvict(func(int) {})
+	if !s.Info().Hooks {
+		t.Fatal("Info().Hooks is false with an OnEvict hook")
+	}
+	s.OnEvict(nil)
+	s.OnPop(func(int) {})
+	if !s.Info().Hooks {
+		t.Fatal("Info().Hooks is false with an OnPop hook")
+	}
+}
+
+// requireOnly - fail unless Peek(), PeekAll() and Snapshot() all agree that the stack holds just want
+func requireOnly[T comparable](t *testing.T, s *SafeStack[T], want T) {
+	t.Helper()
+	if top, err := s.Peek(); err != nil || top != want {
+		t.Fatalf("Peek() = %v, %v; want %v", top, err, want)
+	}
+	if all := s.PeekAll(); !slices.Equal(all, []T{want}) {
+		t.Fatalf("PeekAll() = %v; want [%v]", all, want)
+	}
+	if s.Len() != 1 {
+		t.Fatalf("Len() = %d; want 1", s.Len())
+	}
+}
+
+func TestMaxsizeOne(t *testing.T) {
+	s := NewSafeStack([]int{})
+	s.NewMax(1)
+	for i := range 5 {
+		s.Push(i)
+		requireOnly(t, s, i)
+	}
+
+	s.PushMany([]int{7, 8, 9})
+	requireOnly(t, s, 9)
+
+	s.RePopulate([]int{1, 2, 3})
+	requireOnly(t, s, 3)
+	s.RePopulate([]int{1, 2, 3}, TopFirst)
+	requireOnly(t, s, 1)
+	s.RePopulateLIFO([]int{4, 5, 6})
+	requireOnly(t, s, 6)
+
+	if i, err := s.Pop(); err != nil || i != 6 || s.Len() != 0 {
+		t.Fatalf("Pop() = %d, %v leaving %d; want 6, nil leaving 0", i, err, s.Len())
+	}
+}
+
+func TestNewMaxZeroLiftsLimit(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3})
+	s.NewMax(0)
+	if got := s.Snapshot(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("NewMax(0) left %v; want [1 2 3]", got)
+	}
+	s.NewMax(2)
+	s.NewMax(0)
+	s.PushMany([]int{4, 5})
+	if got := s.Snapshot(); !slices.Equal(got, []int{2, 3, 4, 5}) {
+		t.Fatalf("stack holds %v after the limit was lifted; want [2 3 4 5]", got)
+	}
+}
+
+func TestRePopulateUnbounded(t *testing.T) {
+	s := NewSafeStack([]int{})
+	s.RePopulate([]int{1, 2, 3})
+	if got := s.Snapshot(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("RePopulate on an unbounded stack left %v; want [1 2 3]", got)
+	}
+}
+
+func TestPushOverMaxsize(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3, 4, 5})
+	s.Maxsize = 2
+	s.Push(6)
+	if got := s.Snapshot(); !slices.Equal(got, []int{5, 6}) {
+		t.Fatalf("Push onto a stack over its Maxsize left %v; want [5 6]", got)
+	}
+}
+
+func TestRePopulateVersusLIFO(t *testing.T) {
+	items := []int{1, 2, 3}
+
+	a := NewSafeStack([]int{9})
+	a.RePopulate(slices.Clone(items))
+	b := NewSafeStack([]int{9})
+	b.RePopulateLIFO(items)
+	c := NewSafeStack([]int{9})
+	c.Clear()
+	c.PushMany(items)
+	d := NewSafeStack([]int{9})
+	d.RePopulate(slices.Clone(items), TopFirst)
+
+	for name, tc := range map[string]struct {
+		s   *SafeStack[int]
+		top int
+	}{
+		"RePopulate":           {a, 3},
+		"RePopulateLIFO":       {b, 3},
+		"Clear + PushMany":     {c, 3},
+		"RePopulate(TopFirst)": {d, 1},
+	} {
+		if top, err := tc.s.Peek(); err != nil || top != tc.top {
+			t.Errorf("%s(%v): top is %d, %v; want %d", name, items, top, err, tc.top)
+		}
+	}
+
+	items[2] = -1
+	if top, _ := b.Peek(); top != 3 {
+		t.Fatal("RePopulateLIFO shares the caller's slice")
+	}
+}
+
+func TestNewSafeStackSafe(t *testing.T) {
+	items := []int{1, 2, 3}
+	s := NewSafeStackSafe(items)
+	items[0], items[2] = -1, -3
+	items = append(items[:1], 8, 9)
+	if got := s.Snapshot(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("stack holds %v after the caller's slice changed; want [1 2 3]", got)
+	}
+
+	s.Push(4)
+	if !slices.Equal(items, []int{-1, 8, 9}) {
+		t.Fatalf("a push reached the caller's slice: %v", items)
+	}
+}
+
+func TestDrainOrder(t *testing.T) {
+	for name, tc := range map[string]struct {
+		drain func(*SafeStack[int]) []int
+		want  []int
+	}{
+		"DrainLIFO": {(*SafeStack[int]).DrainLIFO, []int{3, 2, 1}},
+		"PopAll":    {(*SafeStack[int]).PopAll, []int{3, 2, 1}},
+		"DrainFIFO": {(*SafeStack[int]).DrainFIFO, []int{1, 2, 3}},
+		"PopSlice":  {(*SafeStack[int]).PopSlice, []int{1, 2, 3}},
+	} {
+		s := NewSafeStack([]int{})
+		s.PushMany([]int{1, 2, 3})
+		if got := tc.drain(s); !slices.Equal(got, tc.want) {
+			t.Errorf("%s() = %v; want %v", name, got, tc.want)
+		}
+		if s.Len() != 0 {
+			t.Errorf("%s() left %d items", name, s.Len())
+		}
+	}
+}
+
+// TestDrainAtomic - drains racing with pushes must each take a consistent snapshot: every item turns up exactly once,
+// and the items of each pusher come out of every drain in the order pushed
+func TestDrainAtomic(t *testing.T) {
+	const pushers, perPusher = 4, 2000
+	s := NewSafeStack([]int{})
+
+	var producers, consumers sync.WaitGroup
+	stop := make(chan struct{})
+	batches := make(chan []int, 1024)
+	for range 2 {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for lifo := false; ; lifo = !lifo {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if lifo {
+					b := s.DrainLIFO()
+					slices.Reverse(b)
+					batches <- b
+				} else {
+					batches <- s.DrainFIFO()
+				}
+			}
+		}()
+	}
+	for p := range pushers {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for i := range perPusher {
+				s.Push(p*perPusher + i)
+			}
+		}()
+	}
+
+	seen := make(map[int]bool, pushers*perPusher)
+	var collected sync.WaitGroup
+	collected.Add(1)
+	go func() {
+		defer collected.Done()
+		for b := range batches {
+			last := make(map[int]int)
+			for _, item := range b {
+				if seen[item] {
+					t.Errorf("item %d drained twice", item)
+				}
+				seen[item] = true
+				p := item / perPusher
+				if prev, ok := last[p]; ok && item < prev {
+					t.Errorf("a drain returned %d after %d from the same pusher", item, prev)
+				}
+				last[p] = item
+			}
+		}
+	}()
+
+	producers.Wait()
+	close(stop)
+	consumers.Wait()
+	batches <- s.DrainFIFO()
+	close(batches)
+	collected.Wait()
+	if len(seen) != pushers*perPusher {
+		t.Fatalf("%d items drained; want %d", len(seen), pushers*perPusher)
+	}
+}
+
+// collectable - report whether the garbage collector frees everything that mark() was called on, within a second
+func collectable(t *testing.T, mark func(func(*[64]byte))) bool {
+	t.Helper()
+	var freed, marked atomic.Int32
+	mark(func(p *[64]byte) {
+		marked.Add(1)
+		runtime.SetFinalizer(p, func(*[64]byte) { freed.Add(1) })
+	})
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		runtime.GC()
+		if freed.Load() == marked.Load() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+func TestTrimReleases(t *testing.T) {
+	s := NewSafeStackCap[*[64]byte](8)
+	ok := collectable(t, func(mark func(*[64]byte)) {
+		for range 4 {
+			p := new([64]byte)
+			mark(p)
+			s.Push(p)
+		}
+		s.Push(new([64]byte))
+		s.Trim(1)
+	})
+	if !ok {
+		t.Fatal("items dropped by Trim() were never collected")
+	}
+	if s.Len() != 1 {
+		t.Fatalf("Trim(1) left %d items", s.Len())
+	}
+}
+
+func TestPopReleases(t *testing.T) {
+	s := NewSafeStackCap[*[64]byte](8)
+	s.Push(new([64]byte))
+	ok := collectable(t, func(mark func(*[64]byte)) {
+		p := new([64]byte)
+		mark(p)
+		s.Push(p)
+		_, _ = s.Pop()
+	})
+	if !ok {
+		t.Fatal("a popped item was never collected")
+	}
+}
+
+// peekAllLoop - PeekAll() as it was first written, filling the result back to front; the baseline of its benchmark
+func peekAllLoop[T any](s *SafeStack[T]) []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	li := len(s.Items)
+	all := make([]T, li)
+	for i := 0; i < li; i++ {
+		all[(li-1)-i] = s.Items[i]
+	}
+	return all
+}
+
+func TestPeekAllMatchesLoop(t *testing.T) {
+	for _, n := range []int{0, 1, 1000} {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = i
+		}
+		s := NewSafeStack(items)
+		got, want := s.PeekAll(), peekAllLoop(s)
+		if got == nil || !slices.Equal(got, want) {
+			t.Fatalf("PeekAll() of %d items = %v; want %v", n, got, want)
+		}
+		s.SetCopyOnWrite(true)
+		if got := s.PeekAll(); got == nil || !slices.Equal(got, want) {
+			t.Fatalf("PeekAll() of %d items in copy-on-write mode = %v; want %v", n, got, want)
+		}
+	}
+}
+
+func benchmarkPeekAll(b *testing.B, peekAll func(*SafeStack[int]) []int) {
+	s := NewSafeStack(make([]int, 1<<16))
+	b.ReportAllocs()
+	for range b.N {
+		peekAll(s)
+	}
+}
+
+func BenchmarkPeekAllLoop(b *testing.B) {
+	benchmarkPeekAll(b, peekAllLoop[int])
+}
+
+func BenchmarkPeekAllClone(b *testing.B) {
+	benchmarkPeekAll(b, (*SafeStack[int]).PeekAll)
+}
+
+// benchmarkLarge - time op on a fresh stack of n items per iteration; building the stack is not timed
+func benchmarkLarge(b *testing.B, n int, op func(*SafeStack[int])) {
+	items := make([]int, n)
+	b.ReportAllocs()
+	for range b.N {
+		b.StopTimer()
+		s := NewSafeStack(slices.Clone(items))
+		b.StartTimer()
+		op(s)
+	}
+}
+
+func BenchmarkNewMax(b *testing.B) {
+	for _, n := range []int{1 << 10, 1 << 16, 1 << 20} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			benchmarkLarge(b, n, func(s *SafeStack[int]) { s.NewMax(n / 2) })
+		})
+	}
+}
+
+func BenchmarkTrim(b *testing.B) {
+	for _, n := range []int{1 << 10, 1 << 16, 1 << 20} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			benchmarkLarge(b, n, func(s *SafeStack[int]) { s.Trim(n / 2) })
+		})
+	}
+}
+
+// BenchmarkNewMaxContended - NewMax() on a large stack while other goroutines push to it
+func BenchmarkNewMaxContended(b *testing.B) {
+	s := NewSafeStack(make([]int, 1<<16))
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+					s.Push(i)
+				}
+			}
+		}()
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		s.NewMax(1<<15 + i%2)
+		if s.Len() > 1<<15+1 {
+			b.Fatalf("Len() = %d over the new Maxsize", s.Len())
+		}
+		s.NewMax(0)
+		s.PushMany(make([]int, 1<<15))
+	}
+	b.StopTimer()
+	close(stop)
+	wg.Wait()
+}
+
+func TestRePopulateTopFirstLeavesArgument(t *testing.T) {
+	items := []int{1, 2, 3}
+	s := NewSafeStack([]int{})
+	s.RePopulate(items, TopFirst)
+	if !slices.Equal(items, []int{1, 2, 3}) {
+		t.Fatalf("RePopulate(TopFirst) reordered its argument to %v", items)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Fatalf("stack holds %v; want [3 2 1]", got)
+	}
+	items[0] = -1
+	if got := s.Snapshot(); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Fatalf("RePopulate(TopFirst) shares its argument: stack holds %v", got)
+	}
+}
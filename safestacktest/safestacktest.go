@@ -0,0 +1,165 @@
+// Package safestacktest provides helpers for testing code built on safestack: assertions, a concurrent stress
+// harness, and random operation sequences checked against a model for property-based tests.
+package safestacktest
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/e-gun/safestack"
+)
+
+// RequireStackEqual - fail the test at once unless s holds exactly want, given bottom first as in Snapshot().
+func RequireStackEqual[T comparable](t testing.TB, s *safestack.SafeStack[T], want []T) {
+	t.Helper()
+	if got := s.Snapshot(); !slices.Equal(got, want) {
+		t.Fatalf("stack holds %v, bottom first; want %v", got, want)
+	}
+}
+
+// RequireEventuallyEmpty - fail the test at once unless s is drained within timeout.
+func RequireEventuallyEmpty[T any](t testing.TB, s *safestack.SafeStack[T], timeout time.Duration) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := s.WaitUntilEmpty(ctx); err != nil {
+		t.Fatalf("stack still holds %d items after %v", s.Len(), timeout)
+	}
+}
+
+// StressOptions - the shape of the load that Stress() puts on a stack
+type StressOptions[T any] struct {
+	Goroutines int              // # of goroutines pushing and popping at once; 8 if 0
+	Ops        int              // # of operations per goroutine; 1000 if 0
+	Item       func(g, i int) T // the item goroutine g pushes as its i-th operation; required
+	Seed       uint64           // seeds the choice between push and pop, for a reproducible mix
+}
+
+// Stress - hammer s from many goroutines with a random mix of pushes and pops, then check that no item went missing:
+// every item pushed was popped, evicted, or is still there. run it under -race to catch data races as well.
+func Stress[T any](t testing.TB, s *safestack.SafeStack[T], o StressOptions[T]) {
+	t.Helper()
+	goroutines, ops := o.Goroutines, o.Ops
+	if goroutines <= 0 {
+		goroutines = 8
+	}
+	if ops <= 0 {
+		ops = 1000
+	}
+
+	before := s.Stats()
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(o.Seed, uint64(g)))
+			for i := range ops {
+				if r.IntN(2) == 0 {
+					s.Push(o.Item(g, i))
+				} else {
+					_, _ = s.TryPop()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	after := s.Stats()
+	pushed := after.Pushes - before.Pushes
+	gone := (after.Pops - before.Pops) + (after.Evictions - before.Evictions)
+	if grew := after.Len - before.Len; int64(pushed)-int64(gone) != int64(grew) {
+		t.Fatalf("%d items pushed, %d popped or evicted, but the stack grew by %d", pushed, gone, grew)
+	}
+}
+
+// OpKind - what an Op does
+type OpKind int
+
+const (
+	OpPush OpKind = iota
+	OpPop
+	OpPeek
+	OpClear
+)
+
+func (k OpKind) String() string {
+	return [...]string{"Push", "Pop", "Peek", "Clear"}[k]
+}
+
+// Op - one step of a random operation sequence; Item is what OpPush pushes
+type Op[T any] struct {
+	Kind OpKind
+	Item T
+}
+
+func (op Op[T]) String() string {
+	if op.Kind == OpPush {
+		return fmt.Sprintf("Push(%v)", op.Item)
+	}
+	return op.Kind.String() + "()"
+}
+
+// RandomOps - return n random operations, pushing items made by item; pushes outnumber the rest so that stacks grow.
+func RandomOps[T any](r *rand.Rand, n int, item func(*rand.Rand) T) []Op[T] {
+	ops := make([]Op[T], n)
+	for i := range ops {
+		switch k := r.IntN(10); {
+		case k < 5:
+			ops[i] = Op[T]{Kind: OpPush, Item: item(r)}
+		case k < 8:
+			ops[i] = Op[T]{Kind: OpPop}
+		case k < 9:
+			ops[i] = Op[T]{Kind: OpPeek}
+		default:
+			ops[i] = Op[T]{Kind: OpClear}
+		}
+	}
+	return ops
+}
+
+// CheckModel - apply ops to s and to a plain slice side by side, failing the test at the first step where the two
+// disagree. s must not be shared with other goroutines meanwhile, and must have neither a Maxsize nor a weight cap.
+func CheckModel[T comparable](t testing.TB, s *safestack.SafeStack[T], ops []Op[T]) {
+	t.Helper()
+	model := s.Snapshot()
+	for step, op := range ops {
+		switch op.Kind {
+		case OpPush:
+			s.Push(op.Item)
+			model = append(model, op.Item)
+		case OpPop, OpPeek:
+			var got T
+			var err error
+			if op.Kind == OpPop {
+				got, err = s.Pop()
+			} else {
+				got, err = s.Peek()
+			}
+			if len(model) == 0 {
+				if err == nil {
+					t.Fatalf("step %d, %v: got %v from an empty stack", step, op, got)
+				}
+				continue
+			}
+			if want := model[len(model)-1]; err != nil || got != want {
+				t.Fatalf("step %d, %v: got %v, %v; want %v", step, op, got, err, want)
+			}
+			if op.Kind == OpPop {
+				model = model[:len(model)-1]
+			}
+		case OpClear:
+			s.Clear()
+			model = model[:0]
+		}
+		if s.Len() != len(model) {
+			t.Fatalf("step %d, %v: stack holds %d items; want %d", step, op, s.Len(), len(model))
+		}
+	}
+	RequireStackEqual(t, s, model)
+}
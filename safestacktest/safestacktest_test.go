@@ -0,0 +1,70 @@
+package safestacktest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/e-gun/safestack"
+)
+
+// fakeTB - a testing.TB that records a failure instead of failing the test; run the checks it is given via run()
+type fakeTB struct {
+	testing.TB
+	failed string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.failed = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// run - call check with f on a goroutine of its own, as Fatalf() must stop it there; return the failure, if any
+func (f *fakeTB) run(check func(t testing.TB)) string {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		check(f)
+	}()
+	<-done
+	return f.failed
+}
+
+func TestCheckModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(330, 1))
+	ops := RandomOps(r, 2000, func(r *rand.Rand) int { return r.IntN(100) })
+	CheckModel(t, safestack.NewSafeStack([]int{7, 8}), ops)
+}
+
+func TestCheckModelCatchesMaxsize(t *testing.T) {
+	// a Maxsize the model knows nothing of: the fourth push drops an item it still expects
+	s := safestack.NewSafeStack([]int{})
+	s.NewMax(3)
+	ops := []Op[int]{{Kind: OpPush, Item: 1}, {Kind: OpPush, Item: 2}, {Kind: OpPush, Item: 3}, {Kind: OpPush, Item: 4}}
+	if failed := (&fakeTB{}).run(func(t testing.TB) { CheckModel(t, s, ops) }); failed == "" {
+		t.Fatal("CheckModel() passed a stack that dropped an item")
+	}
+}
+
+func TestStress(t *testing.T) {
+	s := safestack.NewSafeStack([]int{})
+	s.NewMax(50)
+	Stress(t, s, StressOptions[int]{Goroutines: 4, Ops: 2000, Item: func(g, i int) int { return g*10000 + i }, Seed: 330})
+}
+
+func TestStressCatchesDrift(t *testing.T) {
+	// Clear() drops items without counting them as popped or evicted, so the books no longer balance
+	s := safestack.NewSafeStack(make([]int, 1000))
+	var once sync.Once
+	o := StressOptions[int]{Goroutines: 2, Ops: 10, Item: func(g, i int) int {
+		once.Do(s.Clear)
+		return i
+	}}
+	if failed := (&fakeTB{}).run(func(t testing.TB) { Stress(t, s, o) }); failed == "" {
+		t.Fatal("Stress() passed a stack that lost items uncounted")
+	}
+}
@@ -0,0 +1,108 @@
+package safestack
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SizeSample - the length of a stack at a moment; see StartSampler()
+type SizeSample struct {
+	At  time.Time
+	Len int
+}
+
+// SizeSummary - the distribution of the sampled lengths; percentiles are nearest-rank
+type SizeSummary struct {
+	Samples                 int
+	Min, P50, P90, P99, Max int
+}
+
+// sampler - the history kept by StartSampler() and the means of stopping its goroutine
+type sampler struct {
+	history *SafeRingBuffer[SizeSample]
+	stop    chan struct{}
+	once    sync.Once
+}
+
+func (sm *sampler) halt() {
+	sm.once.Do(func() { close(sm.stop) })
+}
+
+// StartSampler - record the length of the stack every interval, keeping the latest keep samples, from a goroutine
+// of its own until StopSampler() or Close(); read them back with SizeHistory() and SizeSummary().
+// starting a sampler replaces the previous one, history and all. like time.NewTicker() it panics if interval is not positive.
+func (s *SafeStack[T]) StartSampler(interval time.Duration, keep int) {
+	tick := time.NewTicker(interval)
+	sm := &sampler{history: NewSafeRingBuffer[SizeSample](keep), stop: make(chan struct{})}
+
+	s.mutex.Lock()
+	if s.sampler != nil {
+		s.sampler.halt()
+	}
+	s.sampler = sm
+	done := s.doneChan()
+	s.unlock()
+
+	go func() {
+		defer tick.Stop()
+		for {
+			select {
+			case now := <-tick.C:
+				sm.history.Push(SizeSample{At: now, Len: s.Len()})
+			case <-sm.stop:
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// StopSampler - stop the sampler, if any; its history stays readable.
+func (s *SafeStack[T]) StopSampler() {
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.sampler != nil {
+		s.sampler.halt()
+	}
+}
+
+// SizeHistory - return the samples of the sampler, oldest first; nil if none was ever started.
+func (s *SafeStack[T]) SizeHistory() []SizeSample {
+	s.mutex.RLock()
+	sm := s.sampler
+	s.mutex.RUnlock()
+	if sm == nil {
+		return nil
+	}
+	h := sm.history.Latest(sm.history.Len())
+	slices.Reverse(h)
+	return h
+}
+
+// SizeSummary - summarize the samples of the sampler: the least and greatest length and the 50th, 90th, and 99th percentile.
+func (s *SafeStack[T]) SizeSummary() SizeSummary {
+	h := s.SizeHistory()
+	if len(h) == 0 {
+		return SizeSummary{}
+	}
+
+	lens := make([]int, len(h))
+	for i, sample := range h {
+		lens[i] = sample.Len
+	}
+	slices.Sort(lens)
+	rank := func(p float64) int {
+		return lens[max(int(math.Ceil(p/100*float64(len(lens))))-1, 0)]
+	}
+	return SizeSummary{
+		Samples: len(lens),
+		Min:     lens[0],
+		P50:     rank(50),
+		P90:     rank(90),
+		P99:     rank(99),
+		Max:     lens[len(lens)-1],
+	}
+}
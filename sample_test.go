@@ -0,0 +1,85 @@
+package safestack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSizeSummary(t *testing.T) {
+	s := NewSafeStack([]int{})
+	if s.SizeHistory() != nil || s.SizeSummary() != (SizeSummary{}) {
+		t.Fatal("SizeHistory() or SizeSummary() reported samples before any sampler started")
+	}
+
+	// 1..100 in a scrambled order gives exact nearest-rank percentiles
+	sm := &sampler{history: NewSafeRingBuffer[SizeSample](100), stop: make(chan struct{})}
+	for i := range 100 {
+		sm.history.Push(SizeSample{Len: (i*37)%100 + 1})
+	}
+	s.sampler = sm
+	want := SizeSummary{Samples: 100, Min: 1, P50: 50, P90: 90, P99: 99, Max: 100}
+	if got := s.SizeSummary(); got != want {
+		t.Fatalf("SizeSummary() = %+v; want %+v", got, want)
+	}
+
+	sm.history.Clear()
+	sm.history.Push(SizeSample{Len: 7})
+	want = SizeSummary{Samples: 1, Min: 7, P50: 7, P90: 7, P99: 7, Max: 7}
+	if got := s.SizeSummary(); got != want {
+		t.Fatalf("SizeSummary() of one sample = %+v; want %+v", got, want)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3})
+	s.StartSampler(time.Millisecond, 5)
+	eventually(t, "the sampler fills its history", func() bool { return len(s.SizeHistory()) == 5 })
+
+	h := s.SizeHistory()
+	for i, sample := range h {
+		if sample.Len != 3 {
+			t.Fatalf("sample %d has Len %d; want 3", i, sample.Len)
+		}
+		if i > 0 && sample.At.Before(h[i-1].At) {
+			t.Fatalf("SizeHistory() = %v; want oldest first", h)
+		}
+	}
+
+	s.StopSampler()
+	time.Sleep(20 * time.Millisecond) // a tick already under way may still land
+	last := s.SizeHistory()[4].At
+	time.Sleep(20 * time.Millisecond)
+	if got := s.SizeHistory(); len(got) != 5 || !got[4].At.Equal(last) {
+		t.Fatal("the sampler went on sampling after StopSampler()")
+	}
+
+	// a new sampler starts a new history
+	s.Push(4)
+	s.StartSampler(time.Millisecond, 10)
+	eventually(t, "the new sampler takes a sample", func() bool { return len(s.SizeHistory()) > 0 })
+	if got := s.SizeHistory()[0].Len; got != 4 {
+		t.Fatalf("the new sampler's first sample has Len %d; want 4", got)
+	}
+}
+
+func TestSamplerStopsOnClose(t *testing.T) {
+	s := NewSafeStack([]int{})
+	s.StartSampler(time.Millisecond, 100)
+	eventually(t, "the sampler takes a sample", func() bool { return len(s.SizeHistory()) > 0 })
+	_ = s.Close()
+	time.Sleep(20 * time.Millisecond)
+	n := len(s.SizeHistory())
+	time.Sleep(20 * time.Millisecond)
+	if len(s.SizeHistory()) != n {
+		t.Fatal("the sampler went on sampling after Close()")
+	}
+}
+
+func TestSamplerBadInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("StartSampler(0) did not panic")
+		}
+	}()
+	NewSafeStack([]int{}).StartSampler(0, 1)
+}
@@ -0,0 +1,119 @@
+package safestack
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// ShardedSafeStack - a stack spread over several SafeStacks so that concurrent callers rarely contend for one lock.
+// pushes go to the shards in turn and pops take from the shard most recently pushed to, so a single goroutine
+// sees strict LIFO order; both steer around shards that are busy at that moment. under concurrency ordering is
+// only approximately LIFO: each shard is LIFO, but two items in different shards may come out in either order.
+// no item is ever lost or duplicated.
+type ShardedSafeStack[T any] struct {
+	shards []*SafeStack[T]
+	cursor atomic.Int64
+}
+
+// NewShardedSafeStack - the factory function; return a *ShardedSafeStack[T] with the given # of shards (at least one)
+func NewShardedSafeStack[T any](shards int) *ShardedSafeStack[T] {
+	shards = max(shards, 1)
+	s := &ShardedSafeStack[T]{shards: make([]*SafeStack[T], shards)}
+	for i := range s.shards {
+		s.shards[i] = NewSafeStack([]T{})
+	}
+	return s
+}
+
+// shard - return the shard that the cursor position c maps onto
+func (s *ShardedSafeStack[T]) shard(c int64) *SafeStack[T] {
+	n := int64(len(s.shards))
+	return s.shards[((c%n)+n)%n]
+}
+
+// Push - add an item to the top of the next shard in turn; if another goroutine holds that shard, try the ones after it.
+func (s *ShardedSafeStack[T]) Push(item T) {
+	c := s.cursor.Add(1) - 1
+	for i := int64(0); i < int64(len(s.shards)); i++ {
+		if sh := s.shard(c + i); sh.mutex.TryLock() {
+			_ = sh.push(item)
+			sh.unlock()
+			return
+		}
+	}
+	s.shard(c).Push(item)
+}
+
+// Pop - pop the top item of the shard most recently pushed to, falling back on the others if it is empty.
+// shards that another goroutine holds are passed over at first and only waited for if no other shard has an item.
+func (s *ShardedSafeStack[T]) Pop() (T, error) {
+	c := s.cursor.Load()
+	var busy []*SafeStack[T]
+	for i := int64(1); i <= int64(len(s.shards)); i++ {
+		sh := s.shard(c - i)
+		if !sh.mutex.TryLock() {
+			busy = append(busy, sh)
+			continue
+		}
+		if item, ok := s.popFrom(sh); ok {
+			return item, nil
+		}
+	}
+	for _, sh := range busy {
+		sh.mutex.Lock()
+		if item, ok := s.popFrom(sh); ok {
+			return item, nil
+		}
+	}
+
+	var i T
+	return i, fmt.Errorf("empty stack")
+}
+
+// popFrom - pop from a shard whose write lock the caller holds, and release it
+func (s *ShardedSafeStack[T]) popFrom(sh *SafeStack[T]) (T, bool) {
+	item, err := sh.pop()
+	sh.unlock()
+	if err != nil {
+		return item, false
+	}
+	s.cursor.Add(-1)
+	return item, true
+}
+
+// Len - return the # of items in all shards; only a snapshot if other goroutines are pushing or popping.
+func (s *ShardedSafeStack[T]) Len() int {
+	n := 0
+	for _, sh := range s.shards {
+		n += sh.Len()
+	}
+	return n
+}
+
+// PeekAll - return all items, top first, taking one from each shard in turn; the order is as relaxed as Pop()'s.
+func (s *ShardedSafeStack[T]) PeekAll() []T {
+	c := s.cursor.Load()
+	views := make([][]T, len(s.shards))
+	total := 0
+	for i := range views {
+		views[i] = s.shard(c - 1 - int64(i)).PeekAll()
+		total += len(views[i])
+	}
+
+	all := make([]T, 0, total)
+	for depth := 0; len(all) < total; depth++ {
+		for _, v := range views {
+			if depth < len(v) {
+				all = append(all, v[depth])
+			}
+		}
+	}
+	return all
+}
+
+// Clear - empty every shard.
+func (s *ShardedSafeStack[T]) Clear() {
+	for _, sh := range s.shards {
+		sh.Clear()
+	}
+}
@@ -0,0 +1,95 @@
+package safestack
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestShardedNoneLost - items pushed and popped from many goroutines at once must come out exactly once
+func TestShardedNoneLost(t *testing.T) {
+	const workers, perWorker = 8, 5000
+	s := NewShardedSafeStack[int](4)
+
+	var wg sync.WaitGroup
+	popped := make([][]int, workers)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWorker {
+				s.Push(w*perWorker + i)
+				if i%2 == 1 {
+					if item, err := s.Pop(); err == nil {
+						popped[w] = append(popped[w], item)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool, workers*perWorker)
+	count := func(item int) {
+		if seen[item] {
+			t.Fatalf("item %d came out twice", item)
+		}
+		seen[item] = true
+	}
+	for _, items := range popped {
+		for _, item := range items {
+			count(item)
+		}
+	}
+	if n := s.Len(); n != workers*perWorker-len(seen) {
+		t.Fatalf("Len() = %d with %d of %d items popped", n, len(seen), workers*perWorker)
+	}
+	for _, item := range s.PeekAll() {
+		count(item)
+	}
+	if len(seen) != workers*perWorker {
+		t.Fatalf("%d items accounted for; want %d", len(seen), workers*perWorker)
+	}
+}
+
+func TestShardedSequentialLIFO(t *testing.T) {
+	s := NewShardedSafeStack[int](3)
+	for i := range 10 {
+		s.Push(i)
+	}
+	for want := 9; want >= 0; want-- {
+		if i, err := s.Pop(); err != nil || i != want {
+			t.Fatalf("Pop() = %d, %v; want %d", i, err, want)
+		}
+	}
+	if _, err := s.Pop(); err == nil {
+		t.Fatal("Pop() of an empty sharded stack succeeded")
+	}
+}
+
+// pushPop - the interface the contention benchmarks drive
+type pushPop interface {
+	Push(int)
+	Pop() (int, error)
+}
+
+func benchmarkContended(b *testing.B, s pushPop) {
+	b.SetParallelism(8)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			s.Push(i)
+			_, _ = s.Pop()
+		}
+	})
+}
+
+func BenchmarkContendedSafeStack(b *testing.B) {
+	benchmarkContended(b, NewSafeStack([]int{}))
+}
+
+func BenchmarkContendedSharded4(b *testing.B) {
+	benchmarkContended(b, NewShardedSafeStack[int](4))
+}
+
+func BenchmarkContendedSharded16(b *testing.B) {
+	benchmarkContended(b, NewShardedSafeStack[int](16))
+}
@@ -0,0 +1,124 @@
+package safestack
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// SharedFileStack - a stack kept in a file that any number of processes on the host may push and pop at once.
+// each operation takes an exclusive lock on path+".lock", reads the file, applies itself, and writes the file back
+// atomically; so a crash never leaves a half-written stack, but every operation costs a read and a write of it all.
+// the file has the format of SaveToFile(), so LoadFromFile() with the same codec reads it too.
+// file locking needs a unix system; elsewhere every operation fails with errors.ErrUnsupported.
+type SharedFileStack[T any] struct {
+	path  string
+	codec Codec
+}
+
+// NewSharedFileStack - the factory function; return a *SharedFileStack[T] kept in path with the codec, nil meaning JSONCodec.
+// the file need not exist yet: a missing file is an empty stack.
+func NewSharedFileStack[T any](path string, c Codec) *SharedFileStack[T] {
+	if c == nil {
+		c = JSONCodec{}
+	}
+	return &SharedFileStack[T]{path: path, codec: c}
+}
+
+// Push - add an item to the top of the stack; drop the deepest item if that takes it past its Maxsize.
+// the file keeps only the items and Maxsize, so the overflow policy is always the default, DropOldest.
+func (f *SharedFileStack[T]) Push(item T) error {
+	return f.with(func(s *SafeStack[T]) (bool, error) {
+		s.Push(item)
+		return true, nil
+	})
+}
+
+// Pop - pop the top item from the stack leaving it smaller by one.
+func (f *SharedFileStack[T]) Pop() (T, error) {
+	var item T
+	err := f.with(func(s *SafeStack[T]) (bool, error) {
+		var err error
+		item, err = s.Pop()
+		return err == nil, err
+	})
+	return item, err
+}
+
+// Peek - look at the top item in the stack; but do not pop it.
+func (f *SharedFileStack[T]) Peek() (T, error) {
+	var item T
+	err := f.with(func(s *SafeStack[T]) (bool, error) {
+		var err error
+		item, err = s.Peek()
+		return false, err
+	})
+	return item, err
+}
+
+// Len - return the # of items in the stack.
+func (f *SharedFileStack[T]) Len() (int, error) {
+	n := 0
+	err := f.with(func(s *SafeStack[T]) (bool, error) {
+		n = s.Len()
+		return false, nil
+	})
+	return n, err
+}
+
+// PeekAll - return all items in the stack but leave the stack unchanged; last in first out.
+func (f *SharedFileStack[T]) PeekAll() ([]T, error) {
+	var all []T
+	err := f.with(func(s *SafeStack[T]) (bool, error) {
+		all = s.PeekAll()
+		return false, nil
+	})
+	return all, err
+}
+
+// NewMax - set a new max stack size, kept in the file; trim to that size if necessary; drop the deepest items first.
+func (f *SharedFileStack[T]) NewMax(n int) error {
+	return f.with(func(s *SafeStack[T]) (bool, error) {
+		s.NewMax(n)
+		return true, nil
+	})
+}
+
+// Clear - empty the stack.
+func (f *SharedFileStack[T]) Clear() error {
+	return f.with(func(s *SafeStack[T]) (bool, error) {
+		s.Clear()
+		return true, nil
+	})
+}
+
+// with - run op on the current contents of the file while holding the lock, writing them back if op reports a change
+func (f *SharedFileStack[T]) with(op func(s *SafeStack[T]) (bool, error)) error {
+	unlock, err := lockFile(f.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s := NewSafeStack([]T{})
+	data, err := os.ReadFile(f.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		if err = f.codec.Unmarshal(data, s); err != nil {
+			return err
+		}
+	}
+
+	changed, err := op(s)
+	if !changed {
+		return err
+	}
+	data, e := f.codec.Marshal(s)
+	if e == nil {
+		e = writeFileAtomic(f.path, data)
+	}
+	return errors.Join(err, e)
+}
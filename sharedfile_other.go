@@ -0,0 +1,10 @@
+//go:build !unix
+
+package safestack
+
+import "errors"
+
+// lockFile - file locking is only implemented for unix systems
+func lockFile(path string) (func(), error) {
+	return nil, errors.ErrUnsupported
+}
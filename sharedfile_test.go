@@ -0,0 +1,104 @@
+//go:build unix
+
+package safestack
+
+import (
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSharedFileInterleaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stack.json")
+	a, b := NewSharedFileStack[int](path, nil), NewSharedFileStack[int](path, nil)
+
+	for _, step := range []struct {
+		s    *SharedFileStack[int]
+		push int // 0 pops instead
+		want int // what the pop returns
+	}{
+		{a, 1, 0}, {b, 2, 0}, {a, 0, 2}, {b, 3, 0}, {a, 4, 0}, {b, 0, 4}, {b, 0, 3}, {a, 0, 1},
+	} {
+		if step.push != 0 {
+			if err := step.s.Push(step.push); err != nil {
+				t.Fatalf("Push(%d): %v", step.push, err)
+			}
+			continue
+		}
+		if i, err := step.s.Pop(); err != nil || i != step.want {
+			t.Fatalf("Pop() = %d, %v; want %d", i, err, step.want)
+		}
+	}
+	if _, err := b.Pop(); err == nil {
+		t.Fatal("Pop() of an emptied shared stack succeeded")
+	}
+}
+
+func TestSharedFileMaxsize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stack.gob")
+	a, b := NewSharedFileStack[string](path, GobCodec{}), NewSharedFileStack[string](path, GobCodec{})
+	if err := a.NewMax(2); err != nil {
+		t.Fatalf("NewMax: %v", err)
+	}
+	for _, item := range []string{"x", "y", "z"} {
+		if err := b.Push(item); err != nil {
+			t.Fatalf("Push(%q): %v", item, err)
+		}
+	}
+	all, err := a.PeekAll()
+	if err != nil || !slices.Equal(all, []string{"z", "y"}) {
+		t.Fatalf("PeekAll() = %v, %v; want [z y]: the deepest item dropped", all, err)
+	}
+	if err := b.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if n, err := a.Len(); err != nil || n != 0 {
+		t.Fatalf("Len() after Clear = %d, %v; want 0", n, err)
+	}
+}
+
+// TestSharedFileConcurrent - the lock file must serialize Shared stacks that share a path, as separate processes would
+func TestSharedFileConcurrent(t *testing.T) {
+	const workers, perWorker = 4, 10
+	path := filepath.Join(t.TempDir(), "stack.json")
+
+	var wg sync.WaitGroup
+	popped := make([][]int, workers)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := NewSharedFileStack[int](path, nil)
+			for i := range perWorker {
+				if err := s.Push(w*perWorker + i); err != nil {
+					t.Errorf("Push: %v", err)
+					return
+				}
+				if i%2 == 1 {
+					item, err := s.Pop()
+					if err != nil {
+						t.Errorf("Pop: %v", err)
+						return
+					}
+					popped[w] = append(popped[w], item)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	rest, err := NewSharedFileStack[int](path, nil).PeekAll()
+	if err != nil {
+		t.Fatalf("PeekAll: %v", err)
+	}
+	all := slices.Concat(append(popped, rest)...)
+	slices.Sort(all)
+	want := make([]int, workers*perWorker)
+	for i := range want {
+		want[i] = i
+	}
+	if !slices.Equal(all, want) {
+		t.Fatalf("items popped or left = %v; want each of 0..%d once", all, len(want)-1)
+	}
+}
@@ -0,0 +1,169 @@
+package safestack
+
+import "unsafe"
+
+// Sizer - implemented by items that know their own weight: a size in bytes, a cost, or any other measure.
+// a size func registered with SetSizeFunc() takes precedence over it.
+type Sizer interface {
+	Size() int
+}
+
+// SetSizeFunc - register the function that reports the (approximate) size in bytes of an item.
+// under a weight cap the stack is weighed anew and evicts from the bottom if it is now over budget.
+func (s *SafeStack[T]) SetSizeFunc(f func(T) int) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.sizeof = f
+	s.weighedAt = 0
+	if s.maxWeight > 0 {
+		s.evictToBytes(s.maxWeight)
+	}
+}
+
+// SetMaxWeight - cap the summed size of the items at budget, 0 for no cap; Push() then evicts from the bottom until the
+// stack is back under budget, and so does SetMaxWeight() itself. sizes come from the size func or else from Sizer.
+// Maxsize still applies as well. an item heavier than the whole budget is evicted as soon as it is pushed.
+func (s *SafeStack[T]) SetMaxWeight(budget int) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.maxWeight = max(budget, 0)
+	s.weighedAt = 0
+	if s.maxWeight > 0 {
+		s.evictToBytes(s.maxWeight)
+	}
+}
+
+// SetMaxBytes - cap the approximate memory footprint of the items at budget bytes, 0 for no cap; see SetMaxWeight().
+// without a size func the footprint of items that are not Sizers is estimated: len() for strings and byte slices, else
+// the item's own size, not counting whatever it points to.
+func (s *SafeStack[T]) SetMaxBytes(budget int) {
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.sizeof == nil {
+		s.sizeof = bytesOf[T]
+	}
+	s.maxWeight = max(budget, 0)
+	s.weighedAt = 0
+	if s.maxWeight > 0 {
+		s.evictToBytes(s.maxWeight)
+	}
+}
+
+// Bytes - return the summed size of the items, estimated as in SetMaxBytes() if there is no size func; for monitoring.
+func (s *SafeStack[T]) Bytes() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	sizeof := s.sizeof
+	if sizeof == nil {
+		sizeof = bytesOf[T]
+	}
+	total := 0
+	for _, item := range s.Items {
+		total += sizeof(item)
+	}
+	return total
+}
+
+// EvictToBytes - drop the deepest items until the summed size of the stack is no more than budget; return them in eviction order.
+// without a size func, and with items that are not Sizers, nothing is evicted. with len() as the size func:
+// EvictToBytes(5) on stack ["aa", "bbb", "cc"] -> return ["aa", "bbb"]; and now stack is ["cc"]
+func (s *SafeStack[T]) EvictToBytes(budget int) []T {
+	s.mutex.Lock()
+	defer s.unlock()
+	return s.evictToBytes(budget)
+}
+
+// evictToBytes - the body of EvictToBytes(); the caller holds the write lock
+func (s *SafeStack[T]) evictToBytes(budget int) []T {
+	sizeof := s.weigher()
+	if sizeof == nil {
+		return nil
+	}
+
+	total := s.weight(sizeof)
+	n := 0
+	for n < len(s.Items) && total > budget {
+		total -= sizeof(s.Items[n])
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+
+	evicted := make([]T, n)
+	copy(evicted, s.Items[:n])
+	s.evict(evicted...)
+	clear(s.Items[:n])
+	s.Items = s.Items[n:]
+	s.journal.op(opTrim, len(s.Items))
+	s.changed()
+	s.markWeighed()
+	return evicted
+}
+
+// weigher - return the size func, or else one that asks each item if it is a Sizer, unless no T can be, in which case nil.
+// only an interface T boxes its zero value as a nil any, and its items may or may not be Sizers; those that are not weigh 0.
+// the caller holds a lock
+func (s *SafeStack[T]) weigher() func(T) int {
+	if s.sizeof != nil {
+		return s.sizeof
+	}
+	var zero T
+	if _, ok := any(zero).(Sizer); ok || any(zero) == nil {
+		return sizerSize[T]
+	}
+	return nil
+}
+
+// weight - return the summed size of the items: the running total if it is current, else the sum, which then becomes
+// the running total. push(), pop() and evict() keep the total up to date while there is a weight cap; any other mutation
+// bumps the version past the one the total was marked current at, and so the next call adds the items up anew.
+// the caller holds the write lock
+func (s *SafeStack[T]) weight(sizeof func(T) int) int {
+	if !s.weighed() {
+		s.heft = 0
+		for _, item := range s.Items {
+			s.heft += sizeof(item)
+		}
+		s.markWeighed()
+	}
+	return s.heft
+}
+
+// weighed - report whether the running total is current; the caller holds a lock
+func (s *SafeStack[T]) weighed() bool {
+	return s.maxWeight > 0 && s.weighedAt == s.version.Load()+1
+}
+
+// markWeighed - note that the running total is current at this version; the caller holds the write lock
+func (s *SafeStack[T]) markWeighed() {
+	s.weighedAt = s.version.Load() + 1
+}
+
+// sizerSize - the size of an item that is a Sizer, else 0
+func sizerSize[T any](item T) int {
+	if z, ok := any(item).(Sizer); ok {
+		return z.Size()
+	}
+	return 0
+}
+
+// bytesOf - the size of an item that is a Sizer, else approxBytes()
+func bytesOf[T any](item T) int {
+	if z, ok := any(item).(Sizer); ok {
+		return z.Size()
+	}
+	return approxBytes(item)
+}
+
+// approxBytes - a rough size in bytes of an item
+func approxBytes[T any](item T) int {
+	switch v := any(item).(type) {
+	case string:
+		return len(v)
+	case []byte:
+		return len(v)
+	}
+	return int(unsafe.Sizeof(item))
+}
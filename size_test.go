@@ -0,0 +1,161 @@
+package safestack
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// block - a Sizer of a given weight
+type block int
+
+func (b block) Size() int { return int(b) }
+
+// sumSizes - add up the sizes of the items the slow way
+func sumSizes[T Sizer](items []T) int {
+	total := 0
+	for _, item := range items {
+		total += item.Size()
+	}
+	return total
+}
+
+func TestMaxWeightSizerInterface(t *testing.T) {
+	s := NewSafeStack([]Sizer{})
+	s.SetMaxWeight(10)
+	for range 5 {
+		s.Push(block(4))
+	}
+	if s.Len() != 2 {
+		t.Fatalf("stack of Sizer holds %d items of weight 4 under a budget of 10; want 2", s.Len())
+	}
+	if b := s.Bytes(); b != 8 {
+		t.Fatalf("Bytes() = %d; want 8", b)
+	}
+}
+
+func TestMaxWeightSizerConcrete(t *testing.T) {
+	s := NewSafeStack([]block{})
+	s.SetMaxWeight(10)
+	for _, b := range []block{3, 3, 3, 3} {
+		s.Push(b)
+	}
+	if got := s.Snapshot(); !slices.Equal(got, []block{3, 3, 3}) {
+		t.Fatalf("stack holds %v; want [3 3 3]", got)
+	}
+}
+
+func TestMaxWeightMixedInterface(t *testing.T) {
+	s := NewSafeStack([]any{})
+	s.SetMaxWeight(5)
+	s.Push("weightless")
+	s.Push(block(3))
+	s.Push(block(3))
+	if got := s.Snapshot(); !slices.Equal(got, []any{block(3)}) {
+		t.Fatalf("stack holds %v; want [3]", got)
+	}
+}
+
+func TestMaxBytesInterface(t *testing.T) {
+	s := NewSafeStack([]any{})
+	s.SetMaxBytes(6)
+	s.Push("abcd")
+	s.Push(block(2))
+	s.Push("ef")
+	if got := s.Snapshot(); !slices.Equal(got, []any{block(2), "ef"}) {
+		t.Fatalf("stack holds %v; want [2 ef]", got)
+	}
+}
+
+// TestRunningWeight - whenever the running total counts as current it must agree with the sum, after any mix of
+// operations, including those that do not keep it up to date themselves
+func TestRunningWeight(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	s := NewSafeStack([]block{})
+	s.NewMax(20)
+	s.SetMaxWeight(50)
+	for step := range 2000 {
+		switch r.IntN(8) {
+		case 0, 1, 2:
+			s.Push(block(r.IntN(10)))
+		case 3:
+			_, _ = s.TryPop()
+		case 4:
+			s.RemoveWhere(func(b block) bool { return b == block(r.IntN(10)) })
+		case 5:
+			_ = s.SetAt(0, block(r.IntN(20)))
+		case 6:
+			s.PopN(2)
+		case 7:
+			s.Trim(r.IntN(20))
+		}
+		s.mutex.RLock()
+		current, got, want := s.weighed(), s.heft, sumSizes(s.Items)
+		s.mutex.RUnlock()
+		if current && got != want {
+			t.Fatalf("step %d: running weight %d, but the items sum to %d", step, got, want)
+		}
+	}
+}
+
+func TestRunningWeightStaysCurrent(t *testing.T) {
+	s := NewSafeStack([]block{1, 2, 3})
+	s.SetMaxWeight(100)
+	s.Push(4)
+	_, _ = s.TryPop()
+	s.Push(5)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if !s.weighed() || s.heft != 11 {
+		t.Fatalf("after pushes and pops the running weight is %d, current %v; want 11, current", s.heft, s.weighed())
+	}
+}
+
+func TestEvictToBytes(t *testing.T) {
+	var log evictLog[string]
+	s := NewSafeStack([][]byte{[]byte("aa"), []byte("bbb"), []byte("cc"), []byte("d")})
+	s.SetSizeFunc(func(b []byte) int { return len(b) })
+	s.OnEvict(func(b []byte) { log.hook(string(b)) })
+
+	evicted := s.EvictToBytes(4)
+	got := make([]string, len(evicted))
+	for i, b := range evicted {
+		got[i] = string(b)
+	}
+	if !slices.Equal(got, []string{"aa", "bbb"}) {
+		t.Fatalf("EvictToBytes(4) = %v; want [aa bbb]", got)
+	}
+	if !slices.Equal(log.get(), got) {
+		t.Fatalf("OnEvict saw %v; want %v", log.get(), got)
+	}
+	if s.Len() != 2 || s.Bytes() != 3 {
+		t.Fatalf("stack holds %d items of %d bytes; want 2 of 3", s.Len(), s.Bytes())
+	}
+
+	if evicted := s.EvictToBytes(3); evicted != nil {
+		t.Fatalf("EvictToBytes(3) within budget = %q; want nil", evicted)
+	}
+	if evicted := s.EvictToBytes(0); len(evicted) != 2 || s.Len() != 0 {
+		t.Fatalf("EvictToBytes(0) = %q and left %d items; want both, none left", evicted, s.Len())
+	}
+}
+
+func TestEvictToBytesNoSizes(t *testing.T) {
+	s := NewSafeStack([]int{1, 2, 3})
+	if evicted := s.EvictToBytes(0); evicted != nil || s.Len() != 3 {
+		t.Fatalf("EvictToBytes without sizes evicted %v", evicted)
+	}
+}
+
+func TestRunningWeightSurvivesNewMax(t *testing.T) {
+	s := NewSafeStack([]block{})
+	s.SetMaxWeight(100)
+	s.PushMany([]block{1, 2, 3, 4})
+	s.NewMax(10)
+	s.NewMax(3)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if !s.weighed() || s.heft != 9 {
+		t.Fatalf("after NewMax() the running weight is %d, current %v; want 9, current", s.heft, s.weighed())
+	}
+}
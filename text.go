@@ -0,0 +1,169 @@
+package safestack
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TextCodec - how WriteTo() and ReadFrom() turn a stack's items into human-readable text and back; see SetTextCodec().
+type TextCodec[T any] interface {
+	WriteItems(w io.Writer, items []T) error
+	ReadItems(r io.Reader) ([]T, error)
+}
+
+// LineCodec - a TextCodec writing one item per line, via Format; Parse reads a line back. blank lines are skipped.
+type LineCodec[T any] struct {
+	Format func(T) string
+	Parse  func(string) (T, error)
+}
+
+func (c LineCodec[T]) WriteItems(w io.Writer, items []T) error {
+	bw := bufio.NewWriter(w)
+	for _, item := range items {
+		line := c.Format(item)
+		if strings.ContainsAny(line, "\r\n") {
+			return fmt.Errorf("item %q does not fit on one line", line)
+		}
+		bw.WriteString(line)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func (c LineCodec[T]) ReadItems(r io.Reader) ([]T, error) {
+	var items []T
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSuffix(sc.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		item, err := c.Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+	return items, sc.Err()
+}
+
+// CSVCodec - a TextCodec writing one item per CSV record, via Format; Parse reads a record back.
+type CSVCodec[T any] struct {
+	Format func(T) []string
+	Parse  func([]string) (T, error)
+}
+
+func (c CSVCodec[T]) WriteItems(w io.Writer, items []T) error {
+	cw := csv.NewWriter(w)
+	for _, item := range items {
+		if err := cw.Write(c.Format(item)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (c CSVCodec[T]) ReadItems(r io.Reader) ([]T, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	var items []T
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		item, err := c.Parse(rec)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+}
+
+// SetTextCodec - choose the TextCodec of WriteTo() and ReadFrom().
+func (s *SafeStack[T]) SetTextCodec(c TextCodec[T]) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.text = c
+}
+
+// WriteTo - implement io.WriterTo: write the items to w via the TextCodec, bottom first, so that the last record is the top.
+// the items are copied under the read lock and written after it is released.
+func (s *SafeStack[T]) WriteTo(w io.Writer) (int64, error) {
+	s.mutex.RLock()
+	c := s.text
+	s.mutex.RUnlock()
+	if c == nil {
+		return 0, fmt.Errorf("no text codec; see SetTextCodec()")
+	}
+
+	cw := &countingWriter{w: w}
+	err := c.WriteItems(cw, s.snapshot())
+	return cw.n, err
+}
+
+// ReadFrom - implement io.ReaderFrom: replace the contents of the stack with the items read from r via the TextCodec,
+// in the order that WriteTo() writes them; Maxsize applies. on error the stack is left unchanged.
+func (s *SafeStack[T]) ReadFrom(r io.Reader) (int64, error) {
+	s.mutex.RLock()
+	c := s.text
+	s.mutex.RUnlock()
+	if c == nil {
+		return 0, fmt.Errorf("no text codec; see SetTextCodec()")
+	}
+
+	cr := &countingReader{r: r}
+	items, err := c.ReadItems(cr)
+	if err != nil {
+		return cr.n, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.closed {
+		return cr.n, ErrClosed
+	}
+	s.Items = items
+	s.journal.reset(s.Items)
+	s.changed()
+	if s.Maxsize > 0 {
+		s.trim(s.Maxsize)
+	}
+	return cr.n, nil
+}
+
+// countingWriter - an io.Writer that counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// countingReader - an io.Reader that counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
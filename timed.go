@@ -0,0 +1,203 @@
+package safestack
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TimedSafeStack - a stack whose items carry the time they were pushed and may expire: Pop() and Peek() pass over
+// expired items, which are purged lazily as they surface, or all at once by Purge() or a sweeper started with SweepEvery().
+// items pushed with PushAfter() stay invisible to Pop() and Peek() until their time comes.
+// it is a type of its own rather than a mode of SafeStack because it must keep timestamps alongside every item.
+type TimedSafeStack[T any] struct {
+	entries []timedEntry[T]
+	mutex   sync.Mutex
+	ttl     time.Duration
+}
+
+// timedEntry - an item, the moment it was pushed, the moment it becomes visible, and the moment it expires;
+// a zero notBefore is visible at once and a zero deadline never expires
+type timedEntry[T any] struct {
+	item      T
+	pushed    time.Time
+	notBefore time.Time
+	expires   time.Time
+}
+
+// expired - report whether the entry is past its deadline at now
+func (e timedEntry[T]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// ready - report whether the entry is visible and not yet expired at now
+func (e timedEntry[T]) ready(now time.Time) bool {
+	return !now.Before(e.notBefore) && !e.expired(now)
+}
+
+// NewTimedSafeStack - the factory function; items pushed via Push() live for ttl, or forever if ttl is 0
+func NewTimedSafeStack[T any](ttl time.Duration) *TimedSafeStack[T] {
+	return &TimedSafeStack[T]{entries: []timedEntry[T]{}, ttl: ttl}
+}
+
+// Push - add an item to the top of the stack; it expires after the stack's default ttl.
+func (t *TimedSafeStack[T]) Push(item T) {
+	t.PushWithTTL(item, t.ttl)
+}
+
+// PushWithTTL - add an item to the top of the stack that expires after ttl; 0 means never.
+func (t *TimedSafeStack[T]) PushWithTTL(item T, ttl time.Duration) {
+	t.push(item, time.Time{}, ttl)
+}
+
+// PushAfter - add an item to the top of the stack that Pop() and Peek() pass over until delay has gone by;
+// its ttl, the stack's default, counts from then.
+// PushAfter(item, time.Second) makes a stack of retries with backoff, no timers needed.
+func (t *TimedSafeStack[T]) PushAfter(item T, delay time.Duration) {
+	t.push(item, time.Now().Add(delay), t.ttl)
+}
+
+// PushAt - PushAfter() with the moment the item becomes visible given as a time.
+func (t *TimedSafeStack[T]) PushAt(item T, at time.Time) {
+	t.push(item, at, t.ttl)
+}
+
+// push - the body of the pushes: an item that becomes visible at notBefore, if set, and then lives for ttl
+func (t *TimedSafeStack[T]) push(item T, notBefore time.Time, ttl time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	e := timedEntry[T]{item: item, pushed: time.Now(), notBefore: notBefore}
+	if ttl > 0 {
+		start := e.pushed
+		if notBefore.After(start) {
+			start = notBefore
+		}
+		e.expires = start.Add(ttl)
+	}
+	t.entries = append(t.entries, e)
+}
+
+// Pop - pop the topmost item that is visible and has not expired, discarding any expired ones above it.
+func (t *TimedSafeStack[T]) Pop() (T, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var i T
+	k, ok := t.surface()
+	if !ok {
+		return i, fmt.Errorf("empty stack")
+	}
+	i = t.entries[k].item
+	t.entries = slices.Delete(t.entries, k, k+1)
+	return i, nil
+}
+
+// Peek - look at the topmost item that is visible and has not expired; but do not pop it.
+func (t *TimedSafeStack[T]) Peek() (T, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var i T
+	k, ok := t.surface()
+	if !ok {
+		return i, fmt.Errorf("empty stack")
+	}
+	return t.entries[k].item, nil
+}
+
+// surface - discard expired items from the top, then return the index of the topmost ready item, if there is one.
+// the caller holds the lock
+func (t *TimedSafeStack[T]) surface() (int, bool) {
+	now := time.Now()
+	for len(t.entries) > 0 && t.entries[len(t.entries)-1].expired(now) {
+		t.entries[len(t.entries)-1] = timedEntry[T]{}
+		t.entries = t.entries[:len(t.entries)-1]
+	}
+	for k := len(t.entries) - 1; k >= 0; k-- {
+		if t.entries[k].ready(now) {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// Len - return the # of items in the stack that have not expired, delayed ones included; expired ones are purged first.
+func (t *TimedSafeStack[T]) Len() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.purge()
+	return len(t.entries)
+}
+
+// Purge - remove every expired item, wherever it sits; return the # removed.
+func (t *TimedSafeStack[T]) Purge() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.purge()
+}
+
+// purge - the body of Purge(); the caller holds the lock
+func (t *TimedSafeStack[T]) purge() int {
+	now := time.Now()
+	kept := t.entries[:0]
+	for _, e := range t.entries {
+		if !e.expired(now) {
+			kept = append(kept, e)
+		}
+	}
+	n := len(t.entries) - len(kept)
+	clear(t.entries[len(kept):])
+	t.entries = kept
+	return n
+}
+
+// TrimOlderThan - remove every item pushed more than d ago; return the # removed. these are always the deepest items.
+func (t *TimedSafeStack[T]) TrimOlderThan(d time.Duration) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	cutoff := time.Now().Add(-d)
+	n := 0
+	for n < len(t.entries) && t.entries[n].pushed.Before(cutoff) {
+		n++
+	}
+	clear(t.entries[:n])
+	t.entries = t.entries[n:]
+	return n
+}
+
+// OldestAge - return how long ago the deepest item that has not expired was pushed; false if there is none.
+func (t *TimedSafeStack[T]) OldestAge() (time.Duration, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.purge()
+	if len(t.entries) == 0 {
+		return 0, false
+	}
+	return time.Since(t.entries[0].pushed), true
+}
+
+// SweepEvery - Purge() the stack every interval, from a goroutine of its own, until ctx is done.
+func (t *TimedSafeStack[T]) SweepEvery(ctx context.Context, interval time.Duration) {
+	go func() {
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-tick.C:
+				t.Purge()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Clear - empty the stack.
+func (t *TimedSafeStack[T]) Clear() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.entries = []timedEntry[T]{}
+}
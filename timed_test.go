@@ -0,0 +1,128 @@
+package safestack
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// the timings below are generous: an item meant to live is given an hour, one meant to expire a few milliseconds
+// and then as long as eventually() allows.
+
+func TestTimedTTL(t *testing.T) {
+	s := NewTimedSafeStack[string](time.Hour)
+	s.Push("lasting")
+	s.PushWithTTL("fleeting", 5*time.Millisecond)
+	s.PushWithTTL("forever", 0)
+	s.PushWithTTL("brief", 5*time.Millisecond)
+
+	eventually(t, "the top item expires", func() bool {
+		i, err := s.Peek()
+		return err == nil && i == "forever"
+	})
+	for _, want := range []string{"forever", "lasting"} {
+		if i, err := s.Pop(); err != nil || i != want {
+			t.Fatalf("Pop() = %q, %v; want %q with the expired items passed over", i, err, want)
+		}
+	}
+	if _, err := s.Pop(); err == nil {
+		t.Fatal("Pop() of a stack holding only an expired item succeeded")
+	}
+}
+
+func TestTimedPurge(t *testing.T) {
+	s := NewTimedSafeStack[int](0)
+	s.PushWithTTL(1, 5*time.Millisecond)
+	s.Push(2)
+	s.PushWithTTL(3, 5*time.Millisecond)
+	s.Push(4)
+
+	eventually(t, "two items expire", func() bool { return s.Len() == 2 })
+	if n := s.Purge(); n != 0 {
+		t.Fatalf("Purge() after Len() removed %d; want 0, Len() having purged already", n)
+	}
+	s.PushWithTTL(5, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	if n := s.Purge(); n != 1 {
+		t.Fatalf("Purge() = %d; want 1", n)
+	}
+}
+
+func TestTimedSweepEvery(t *testing.T) {
+	s := NewTimedSafeStack[int](5 * time.Millisecond)
+	s.Push(1)
+	s.PushWithTTL(2, time.Hour)
+	s.Push(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.SweepEvery(ctx, time.Millisecond)
+	eventually(t, "the sweeper purges the stack", func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return len(s.entries) == 1
+	})
+}
+
+func TestTimedPushAfter(t *testing.T) {
+	s := NewTimedSafeStack[string](0)
+	s.Push("now")
+	s.PushAfter("later", 200*time.Millisecond)
+	s.PushAt("never", time.Now().Add(time.Hour))
+
+	if i, err := s.Peek(); err != nil || i != "now" {
+		t.Fatalf("Peek() = %q, %v; want now, the delayed items unseen", i, err)
+	}
+	if s.Len() != 3 {
+		t.Fatalf("Len() = %d; want 3, delayed items included", s.Len())
+	}
+	if i, err := s.Pop(); err != nil || i != "now" {
+		t.Fatalf("Pop() = %q, %v; want now", i, err)
+	}
+	if _, err := s.Pop(); err == nil {
+		t.Fatal("Pop() of a stack holding only delayed items succeeded")
+	}
+	eventually(t, "the delayed item comes due", func() bool {
+		i, err := s.Peek()
+		return err == nil && i == "later"
+	})
+	if i, err := s.Pop(); err != nil || i != "later" {
+		t.Fatalf("Pop() = %q, %v; want later", i, err)
+	}
+}
+
+func TestTimedPushAfterTTL(t *testing.T) {
+	// the ttl counts from when the item comes due, not from the push
+	s := NewTimedSafeStack[int](time.Hour)
+	s.PushAfter(1, 10*time.Millisecond)
+	s.mutex.Lock()
+	e := s.entries[0]
+	s.mutex.Unlock()
+	if got := e.expires.Sub(e.notBefore); got != time.Hour {
+		t.Fatalf("delayed item expires %v after it comes due; want 1h", got)
+	}
+}
+
+func TestTimedTrimOlderThan(t *testing.T) {
+	s := NewTimedSafeStack[int](0)
+	if _, ok := s.OldestAge(); ok {
+		t.Fatal("OldestAge() of an empty stack reported an age")
+	}
+	s.Push(1)
+	s.Push(2)
+	time.Sleep(100 * time.Millisecond)
+	s.Push(3)
+
+	if age, ok := s.OldestAge(); !ok || age < 100*time.Millisecond {
+		t.Fatalf("OldestAge() = %v, %v; want at least 100ms", age, ok)
+	}
+	if n := s.TrimOlderThan(50 * time.Millisecond); n != 2 {
+		t.Fatalf("TrimOlderThan() = %d; want the 2 older items", n)
+	}
+	if i, err := s.Pop(); err != nil || i != 3 || s.Len() != 0 {
+		t.Fatalf("Pop() = %d, %v with Len() %d left; want 3 and nothing else", i, err, s.Len())
+	}
+	if n := s.TrimOlderThan(time.Hour); n != 0 {
+		t.Fatalf("TrimOlderThan() of an empty stack = %d; want 0", n)
+	}
+}
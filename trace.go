@@ -0,0 +1,107 @@
+package safestack
+
+import (
+	"slices"
+	"time"
+)
+
+// Tracer - receives an event for every push, pop, and blocking wait of a stack; see SetTracer().
+// the events are delivered after the stack's lock is released, in the order they happened, so a Tracer may call back
+// into the stack; an adapter to OpenTelemetry or any other tracing system implements it outside this package.
+// Popped() learns how long the item was queued, from its push to its pop. that is 0 when the stack does not know:
+// for items pushed before the Tracer was set, or that were on the stack when it changed by any means other than
+// pushes, pops, Trim() and NewMax(): Sort(), Do(), RemoveWhere() and the like lose track of where items came from.
+type Tracer[T any] interface {
+	Pushed(item T)
+	Popped(item T, queued time.Duration)
+	Waited(d time.Duration, err error) // a PopWait(), PushWait(), blocking Pop() or WaitUntil call that had to wait
+}
+
+// traceWait - the kind of a traceEvent for a blocking wait; pushes, pops, and clears reuse the journal op codes
+const traceWait = 'W'
+
+// traceEvent - one event noted for the Tracer and the OnPush, OnPop, and OnClear hooks while the lock was held
+type traceEvent[T any] struct {
+	kind byte // opPush, opPop, opClear, or traceWait
+	item T
+	d    time.Duration // how long a wait took, or how long a popped item was queued
+	err  error
+}
+
+// SetTracer - register the Tracer of the stack; SetTracer(nil) removes it.
+func (s *SafeStack[T]) SetTracer(t Tracer[T]) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.tracer = t
+	s.pushedAt, s.stampedAt = nil, 0
+}
+
+// trace - note an event for the Tracer and the hooks, if there are any; the caller holds the write lock
+func (s *SafeStack[T]) trace(e traceEvent[T]) {
+	if s.tracer != nil || s.hooks.wants(e.kind) {
+		s.traces = append(s.traces, e)
+	}
+}
+
+// deliver - hand the noted events to t and h; called by unlock() once the lock is released
+func deliver[T any](t Tracer[T], h lifecycleHooks[T], events []traceEvent[T]) {
+	for _, e := range events {
+		switch e.kind {
+		case opPush:
+			if t != nil {
+				t.Pushed(e.item)
+			}
+			if h.push != nil {
+				h.push(e.item)
+			}
+		case opPop:
+			if t != nil {
+				t.Popped(e.item, e.d)
+			}
+			if h.pop != nil {
+				h.pop(e.item)
+			}
+		case opClear:
+			if h.clear != nil {
+				h.clear()
+			}
+		default:
+			if t != nil {
+				t.Waited(e.d, e.err)
+			}
+		}
+	}
+}
+
+// stamp - make sure the push times are current, before a push changes the stack, and report whether they are kept;
+// items whose push time the stack lost get the zero time. only while there is a Tracer. the caller holds the write lock
+func (s *SafeStack[T]) stamp() bool {
+	if s.tracer == nil {
+		return false
+	}
+	if !s.stamped() {
+		s.pushedAt = slices.Grow(s.pushedAt[:0], len(s.Items))[:len(s.Items)]
+		clear(s.pushedAt)
+		s.markStamped()
+	}
+	return true
+}
+
+// stamped - report whether pushedAt holds the push times of the items, bottom first. like the running weight,
+// they are current only at the version they were last marked at; the caller holds a lock
+func (s *SafeStack[T]) stamped() bool {
+	return s.tracer != nil && s.stampedAt == s.version.Load()+1 && len(s.pushedAt) == len(s.Items)
+}
+
+// markStamped - note that the push times are current at this version; the caller holds the write lock
+func (s *SafeStack[T]) markStamped() {
+	s.stampedAt = s.version.Load() + 1
+}
+
+// queued - return how long the item at index i has been on the stack, or 0 if that is not known; the caller holds a lock
+func (s *SafeStack[T]) queued(i int) time.Duration {
+	if !s.stamped() || s.pushedAt[i].IsZero() {
+		return 0
+	}
+	return time.Since(s.pushedAt[i])
+}
@@ -0,0 +1,138 @@
+package safestack
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// traceLog - a Tracer that records what it is told
+type traceLog struct {
+	mu     sync.Mutex
+	pushed []int
+	popped []int
+	queued []time.Duration
+	waits  []error
+}
+
+func (l *traceLog) Pushed(item int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pushed = append(l.pushed, item)
+}
+
+func (l *traceLog) Popped(item int, queued time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.popped = append(l.popped, item)
+	l.queued = append(l.queued, queued)
+}
+
+func (l *traceLog) Waited(_ time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waits = append(l.waits, err)
+}
+
+func TestTracerQueueLatency(t *testing.T) {
+	var log traceLog
+	s := NewSafeStack([]int{0})
+	s.SetTracer(&log)
+
+	s.Push(1)
+	time.Sleep(20 * time.Millisecond)
+	s.Push(2)
+	_, _ = s.Pop()
+	_, _ = s.Pop()
+	_, _ = s.Pop()
+
+	if len(log.popped) != 3 || log.popped[0] != 2 || log.popped[1] != 1 || log.popped[2] != 0 {
+		t.Fatalf("Popped() saw %v; want [2 1 0]", log.popped)
+	}
+	if q := log.queued[0]; q <= 0 || q >= 20*time.Millisecond {
+		t.Errorf("2 was queued for %v; want a moment", q)
+	}
+	if q := log.queued[1]; q < 20*time.Millisecond {
+		t.Errorf("1 was queued for %v; want at least 20ms", q)
+	}
+	if q := log.queued[2]; q != 0 {
+		t.Errorf("0, pushed before the Tracer was set, was queued for %v; want 0 for unknown", q)
+	}
+}
+
+func TestTracerQueueLatencyBounded(t *testing.T) {
+	var log traceLog
+	s := NewSafeStack([]int{})
+	s.NewMax(2)
+	s.SetTracer(&log)
+
+	s.Push(1)
+	time.Sleep(20 * time.Millisecond)
+	s.PushMany([]int{2, 3})
+	s.Trim(1)
+	s.Push(4)
+	drained := s.DrainFIFO()
+
+	if len(drained) != 2 || drained[0] != 3 || drained[1] != 4 {
+		t.Fatalf("DrainFIFO() = %v; want [3 4]", drained)
+	}
+	for i, q := range log.queued {
+		if q <= 0 || q >= 20*time.Millisecond {
+			t.Errorf("%d was queued for %v; want a moment, its own push time and not 1's", log.popped[i], q)
+		}
+	}
+}
+
+func TestTracerRearranged(t *testing.T) {
+	var log traceLog
+	s := NewSafeStack([]int{})
+	s.SetTracer(&log)
+	s.PushMany([]int{3, 1, 2})
+	s.Sort(func(a, b int) bool { return a < b })
+	s.Push(4)
+	s.PopN(4)
+
+	want := map[int]bool{4: true}
+	for i, item := range log.popped {
+		if known := log.queued[i] > 0; known != want[item] {
+			t.Errorf("%d was queued for %v; known should be %v", item, log.queued[i], want[item])
+		}
+	}
+}
+
+func TestTracerWait(t *testing.T) {
+	var log traceLog
+	s := NewSafeStack([]int{})
+	s.SetTracer(&log)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.PopWait(ctx); err == nil {
+		t.Fatal("PopWait() of an empty stack succeeded")
+	}
+	if len(log.waits) != 1 || log.waits[0] == nil {
+		t.Fatalf("Waited() saw %v; want one timed-out wait", log.waits)
+	}
+}
+
+func TestTracerQueueLatencyNewMax(t *testing.T) {
+	var log traceLog
+	s := NewSafeStack([]int{})
+	s.SetTracer(&log)
+	s.Push(1)
+	s.Push(2)
+	s.NewMax(10)
+	s.Push(3)
+	s.NewMax(2)
+	s.Trim(5)
+	s.PopN(2)
+
+	if len(log.popped) != 2 || log.popped[0] != 3 || log.popped[1] != 2 {
+		t.Fatalf("Popped() saw %v; want [3 2]", log.popped)
+	}
+	for i, q := range log.queued {
+		if q <= 0 {
+			t.Errorf("%d was queued for %v across NewMax() and Trim(); want its latency", log.popped[i], q)
+		}
+	}
+}
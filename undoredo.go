@@ -0,0 +1,67 @@
+package safestack
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNoUndo - returned by Undo() when there is nothing to undo
+var ErrNoUndo = errors.New("nothing to undo")
+
+// ErrNoRedo - returned by Redo() when there is nothing to redo
+var ErrNoRedo = errors.New("nothing to redo")
+
+// UndoRedo - the classic two-stack undo/redo history: Do() records an action, Undo() steps back, Redo() forward.
+type UndoRedo[T any] struct {
+	undo  *SafeStack[T]
+	redo  *SafeStack[T]
+	mutex sync.Mutex // makes the moves between the two stacks atomic
+}
+
+// NewUndoRedo - the factory function; remember at most maxHistory actions (the oldest are forgotten), 0 for no limit
+func NewUndoRedo[T any](maxHistory int) *UndoRedo[T] {
+	u := &UndoRedo[T]{undo: NewSafeStack([]T{}), redo: NewSafeStack([]T{})}
+	u.undo.NewMax(maxHistory)
+	u.redo.NewMax(maxHistory)
+	return u
+}
+
+// Do - record an action; this forgets whatever could have been redone.
+func (u *UndoRedo[T]) Do(action T) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.undo.Push(action)
+	u.redo.Clear()
+}
+
+// Undo - step back: return the most recent action, which Redo() can then restore; ErrNoUndo if there is none.
+func (u *UndoRedo[T]) Undo() (T, error) {
+	return u.move(u.undo, u.redo, ErrNoUndo)
+}
+
+// Redo - step forward again: return the most recently undone action; ErrNoRedo if there is none.
+func (u *UndoRedo[T]) Redo() (T, error) {
+	return u.move(u.redo, u.undo, ErrNoRedo)
+}
+
+// move - pop from one stack and push onto the other; empty is the error if there is nothing to pop
+func (u *UndoRedo[T]) move(from, to *SafeStack[T], empty error) (T, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	action, ok := from.TryPop()
+	if !ok {
+		return action, empty
+	}
+	to.Push(action)
+	return action, nil
+}
+
+// CanUndo - report whether there is anything to undo.
+func (u *UndoRedo[T]) CanUndo() bool {
+	return u.undo.Len() > 0
+}
+
+// CanRedo - report whether there is anything to redo.
+func (u *UndoRedo[T]) CanRedo() bool {
+	return u.redo.Len() > 0
+}
@@ -0,0 +1,68 @@
+package safestack
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUndoRedo(t *testing.T) {
+	u := NewUndoRedo[string](0)
+	if _, err := u.Undo(); !errors.Is(err, ErrNoUndo) {
+		t.Fatalf("Undo() with no history: %v; want ErrNoUndo", err)
+	}
+	if _, err := u.Redo(); !errors.Is(err, ErrNoRedo) {
+		t.Fatalf("Redo() with nothing undone: %v; want ErrNoRedo", err)
+	}
+
+	u.Do("a")
+	u.Do("b")
+	u.Do("c")
+	for _, want := range []string{"c", "b"} {
+		if got, err := u.Undo(); err != nil || got != want {
+			t.Fatalf("Undo() = %q, %v; want %q", got, err, want)
+		}
+	}
+	if !u.CanUndo() || !u.CanRedo() {
+		t.Fatalf("CanUndo() %v, CanRedo() %v halfway back; want both true", u.CanUndo(), u.CanRedo())
+	}
+	if got, err := u.Redo(); err != nil || got != "b" {
+		t.Fatalf("Redo() = %q, %v; want b", got, err)
+	}
+
+	// a new action forgets what could have been redone
+	u.Do("d")
+	if u.CanRedo() {
+		t.Fatal("CanRedo() after Do(); want false")
+	}
+	if _, err := u.Redo(); !errors.Is(err, ErrNoRedo) {
+		t.Fatalf("Redo() after Do(): %v; want ErrNoRedo", err)
+	}
+	for _, want := range []string{"d", "b", "a"} {
+		if got, err := u.Undo(); err != nil || got != want {
+			t.Fatalf("Undo() = %q, %v; want %q", got, err, want)
+		}
+	}
+	if u.CanUndo() {
+		t.Fatal("CanUndo() once all is undone; want false")
+	}
+}
+
+func TestUndoRedoMaxHistory(t *testing.T) {
+	u := NewUndoRedo[int](2)
+	for i := 1; i <= 4; i++ {
+		u.Do(i)
+	}
+	for _, want := range []int{4, 3} {
+		if got, err := u.Undo(); err != nil || got != want {
+			t.Fatalf("Undo() = %d, %v; want %d", got, err, want)
+		}
+	}
+	if _, err := u.Undo(); !errors.Is(err, ErrNoUndo) {
+		t.Fatalf("Undo() past a history of 2: %v; want ErrNoUndo, the oldest actions forgotten", err)
+	}
+	for _, want := range []int{3, 4} {
+		if got, err := u.Redo(); err != nil || got != want {
+			t.Fatalf("Redo() = %d, %v; want %d", got, err, want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package safestack
+
+// Version - return the stack's version, which every change to it advances; it starts at 0 and never goes back.
+// a cache built on the stack can keep the version it last copied at and ask ChangedSince() before copying again.
+// reading it takes no lock, so the answer may be stale by the time it is used, though never from the future.
+func (s *SafeStack[T]) Version() uint64 {
+	return s.version.Load()
+}
+
+// ChangedSince - report whether the stack has changed since Version() returned v.
+// the version may also advance on an operation that leaves the items as they were, such as SetAt() of the same item.
+func (s *SafeStack[T]) ChangedSince(v uint64) bool {
+	return s.version.Load() != v
+}
+
+// Changes - return the current version along with a channel that will be closed when the stack moves past it;
+// each channel fires once, so call Changes() again for the next change. the channel of a closed stack is already closed.
+//
+//	_, ch := s.Changes()
+//	for {
+//		<-ch
+//		refresh(s.Snapshot())
+//		_, ch = s.Changes()
+//	}
+func (s *SafeStack[T]) Changes() (uint64, <-chan struct{}) {
+	s.mutex.Lock()
+	defer s.unlock()
+	if s.closed {
+		return s.version.Load(), s.doneChan()
+	}
+	return s.version.Load(), s.waitChan()
+}
@@ -0,0 +1,58 @@
+package safestack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVersion(t *testing.T) {
+	s := NewSafeStack([]int{})
+	v := s.Version()
+	if s.ChangedSince(v) {
+		t.Fatal("ChangedSince() of the current version")
+	}
+	s.Push(1)
+	if !s.ChangedSince(v) || s.Version() <= v {
+		t.Fatalf("a Push left the version at %d, from %d", s.Version(), v)
+	}
+
+	v = s.Version()
+	_, _ = s.Peek()
+	s.PeekAll()
+	s.RemoveWhere(func(int) bool { return false })
+	if s.ChangedSince(v) {
+		t.Fatal("reads and a RemoveWhere() of nothing advanced the version")
+	}
+	_ = s.SetAt(0, 1)
+	if !s.ChangedSince(v) {
+		t.Fatal("SetAt() did not advance the version")
+	}
+}
+
+func TestChanges(t *testing.T) {
+	s := NewSafeStack([]int{})
+	v, ch := s.Changes()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		s.Push(1)
+	}()
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("the Changes() channel did not fire on a Push")
+	}
+	if !s.ChangedSince(v) {
+		t.Fatal("the channel fired but the version did not move")
+	}
+
+	_, ch = s.Changes()
+	s.Close()
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("the Changes() channel did not fire on Close")
+	}
+	if _, ch = s.Changes(); !closedWithin(ch) {
+		t.Fatal("the Changes() channel of a closed stack is open")
+	}
+}
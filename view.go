@@ -0,0 +1,39 @@
+package safestack
+
+import "iter"
+
+// StackView - a read-only handle on a SafeStack: it sees every change to the stack but cannot make any.
+// hand one to code that should watch a stack without being able to Clear() it or reach its Items.
+type StackView[T any] struct {
+	s *SafeStack[T]
+}
+
+// ReadOnly - return a read-only view of the stack.
+func (s *SafeStack[T]) ReadOnly() *StackView[T] {
+	return &StackView[T]{s: s}
+}
+
+// Len - return the # of items in the stack.
+func (v *StackView[T]) Len() int {
+	return v.s.Len()
+}
+
+// Peek - look at the top item in the stack.
+func (v *StackView[T]) Peek() (T, error) {
+	return v.s.Peek()
+}
+
+// PeekAll - return a copy of all items in the stack; last in first out.
+func (v *StackView[T]) PeekAll() []T {
+	return v.s.PeekAll()
+}
+
+// All - range over a snapshot of the stack from the top down.
+func (v *StackView[T]) All() iter.Seq[T] {
+	return v.s.All()
+}
+
+// Backward - range over a snapshot of the stack from the bottom up.
+func (v *StackView[T]) Backward() iter.Seq[T] {
+	return v.s.Backward()
+}
@@ -0,0 +1,131 @@
+package safestack
+
+import (
+	"context"
+	"time"
+)
+
+// EmptyBehavior - what Pop() does when the stack is empty; see SetEmptyBehavior()
+type EmptyBehavior int
+
+const (
+	EmptyError EmptyBehavior = iota // return the zero value and an error; the default
+	EmptyZero                       // return the zero value and a nil error
+	EmptyBlock                      // wait until there is an item to pop
+)
+
+// SetEmptyBehavior - choose how Pop() responds to an empty stack.
+func (s *SafeStack[T]) SetEmptyBehavior(b EmptyBehavior) {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.onEmpty = b
+}
+
+// waitChan - return a channel that will be closed at the next change to the stack; the caller holds the write lock
+func (s *SafeStack[T]) waitChan() <-chan struct{} {
+	if s.wake == nil {
+		s.wake = make(chan struct{})
+	}
+	return s.wake
+}
+
+// changed - note the new high-water mark, advance the version, and wake everyone waiting for the stack to change;
+// every mutation calls this with the write lock held
+func (s *SafeStack[T]) changed() {
+	s.highest = max(s.highest, len(s.Items))
+	s.version.Add(1)
+	s.stale = true
+	if s.wake != nil {
+		close(s.wake)
+		s.wake = nil
+	}
+}
+
+// changedKeeping - changed() for a mutation that kept the running weight and the push times up to date, if they were:
+// they stay current past the version it bumps. the caller holds the write lock
+func (s *SafeStack[T]) changedKeeping() {
+	stamped, weighed := s.stamped(), s.weighed()
+	s.changed()
+	if weighed {
+		s.markWeighed()
+	}
+	if stamped {
+		s.markStamped()
+	}
+}
+
+// PopWait - Pop() but wait as long as the stack is empty; give up with ctx.Err() once ctx is done.
+func (s *SafeStack[T]) PopWait(ctx context.Context) (T, error) {
+	s.mutex.Lock()
+	defer s.unlock()
+	if err := s.await(ctx, s.nonEmpty); err != nil {
+		var i T
+		return i, err
+	}
+	return s.pop()
+}
+
+// PushWait - Push() but, rather than evict, wait as long as the stack is full; give up with ctx.Err() once ctx is done.
+func (s *SafeStack[T]) PushWait(ctx context.Context, item T) error {
+	s.mutex.Lock()
+	defer s.unlock()
+	if err := s.await(ctx, s.hasRoom); err != nil {
+		return err
+	}
+	return s.push(item)
+}
+
+// WaitUntilNonEmpty - wait until the stack holds at least one item; ctx.Err() once ctx is done, ErrClosed if the stack closes.
+func (s *SafeStack[T]) WaitUntilNonEmpty(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.unlock()
+	if err := s.await(ctx, s.nonEmpty); err != nil {
+		return err
+	}
+	if s.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+// WaitUntilEmpty - wait until the stack has been drained; ctx.Err() once ctx is done. a closed stack counts as empty.
+func (s *SafeStack[T]) WaitUntilEmpty(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.unlock()
+	return s.await(ctx, func() bool { return len(s.Items) == 0 })
+}
+
+// hasRoom - report whether a push would not need to evict; the caller holds a lock
+func (s *SafeStack[T]) hasRoom() bool {
+	return s.Maxsize <= 0 || len(s.Items) < s.Maxsize
+}
+
+// nonEmpty - report whether there is anything to pop; the caller holds a lock
+func (s *SafeStack[T]) nonEmpty() bool {
+	return len(s.Items) > 0
+}
+
+// await - wait until ready() holds, the stack is closed, or ctx is done, in which case return ctx.Err().
+// the caller holds the write lock, which is released while waiting and held again on return.
+// the Tracer, if any, learns how long a wait took.
+func (s *SafeStack[T]) await(ctx context.Context, ready func() bool) (err error) {
+	if ready() || s.closed {
+		return nil
+	}
+	start := time.Now()
+	defer func() { s.trace(traceEvent[T]{kind: traceWait, d: time.Since(start), err: err}) }()
+
+	for !ready() && !s.closed {
+		w := s.waitChan()
+		s.publish()
+		s.mutex.Unlock()
+		select {
+		case <-w:
+			s.mutex.Lock()
+		case <-ctx.Done():
+			s.mutex.Lock()
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package safestack
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEmptyError(t *testing.T) {
+	s := NewSafeStack([]int{})
+	if _, err := s.Pop(); err == nil {
+		t.Fatal("Pop() of an empty stack succeeded by default")
+	}
+	s.SetEmptyBehavior(EmptyError)
+	if _, err := s.Pop(); err == nil {
+		t.Fatal("Pop() of an empty stack succeeded under EmptyError")
+	}
+}
+
+func TestEmptyZero(t *testing.T) {
+	s := NewSafeStack([]string{})
+	s.SetEmptyBehavior(EmptyZero)
+	if i, err := s.Pop(); err != nil || i != "" {
+		t.Fatalf("Pop() of an empty stack under EmptyZero = %q, %v; want the zero value and nil", i, err)
+	}
+	s.Close()
+	if _, err := s.Pop(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Pop() of a closed stack under EmptyZero = %v; want ErrClosed", err)
+	}
+}
+
+func TestEmptyBlock(t *testing.T) {
+	s := NewSafeStack([]int{})
+	s.SetEmptyBehavior(EmptyBlock)
+
+	type result struct {
+		i   int
+		err error
+	}
+	done := make(chan result)
+	go func() {
+		i, err := s.Pop()
+		done <- result{i, err}
+	}()
+
+	select {
+	case r := <-done:
+		t.Fatalf("Pop() of an empty stack under EmptyBlock returned %d, %v at once", r.i, r.err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	s.Push(7)
+	select {
+	case r := <-done:
+		if r.err != nil || r.i != 7 {
+			t.Fatalf("blocked Pop() = %d, %v; want 7, nil", r.i, r.err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked Pop() did not return after a Push")
+	}
+}
+
+func TestEmptyBlockClose(t *testing.T) {
+	s := NewSafeStack([]int{})
+	s.SetEmptyBehavior(EmptyBlock)
+	done := make(chan error)
+	go func() {
+		_, err := s.Pop()
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	s.Close()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("blocked Pop() = %v after Close; want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked Pop() did not return after Close")
+	}
+}
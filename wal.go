@@ -0,0 +1,76 @@
+package safestack
+
+import (
+	"bytes"
+	"os"
+)
+
+// wal - the file behind a journal in write-ahead log mode; see EnableWAL()
+type wal struct {
+	path  string
+	f     *os.File
+	every int
+	since int
+}
+
+// EnableWAL - journal every mutation to the file at path, which is rewritten as a snapshot of the current contents
+// first and then again every snapshotEvery records (never, if that is 0) so that it does not grow without bound.
+// records are written but not synced: a process crash loses nothing, a power cut may lose the latest records.
+// the log is closed by Close() or by a later SetJournal().
+// at startup: s, err := RecoverFromWAL(path, decode); then s.EnableWAL(path, encode, n)
+func (s *SafeStack[T]) EnableWAL(path string, encode func(T) []byte, snapshotEvery int) error {
+	s.mutex.Lock()
+	defer s.unlock()
+	s.journal.close()
+	s.journal = journal[T]{encode: encode, wal: &wal{path: path, every: snapshotEvery}}
+	s.journal.compact(s.Items, s.Maxsize)
+	return s.journal.err
+}
+
+// RecoverFromWAL - rebuild a stack from the write-ahead log at path; decode is the inverse of the encode func of EnableWAL().
+// the stack does not go on logging: call EnableWAL() on it for that.
+func RecoverFromWAL[T any](path string, decode func([]byte) T) (*SafeStack[T], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Replay(f, decode)
+}
+
+// compactDue - report whether the write-ahead log is due to be rewritten as a snapshot
+func (j *journal[T]) compactDue() bool {
+	return j.wal != nil && j.active() && j.wal.every > 0 && j.wal.since >= j.wal.every
+}
+
+// compact - atomically replace the write-ahead log with a snapshot of items and maxsize; then append to the new file
+func (j *journal[T]) compact(items []T, maxsize int) {
+	var buf bytes.Buffer
+	snap := journal[T]{w: &buf, encode: j.encode}
+	snap.reset(items)
+	snap.op(opMax, maxsize)
+
+	if err := writeFileAtomic(j.wal.path, buf.Bytes()); err != nil {
+		j.err = err
+		return
+	}
+	f, err := os.OpenFile(j.wal.path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		j.err = err
+		return
+	}
+	if j.wal.f != nil {
+		_ = j.wal.f.Close()
+	}
+	j.wal.f, j.w, j.wal.since = f, f, 0
+}
+
+// close - close the file of a write-ahead log, if any, and stop journaling
+func (j *journal[T]) close() error {
+	var err error
+	if j.wal != nil && j.wal.f != nil {
+		err = j.wal.f.Close()
+	}
+	*j = journal[T]{}
+	return err
+}
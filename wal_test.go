@@ -0,0 +1,117 @@
+package safestack
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// requireRecovers - fail unless the write-ahead log at path rebuilds a stack just like s
+func requireRecovers(t *testing.T, path string, s *SafeStack[int]) {
+	t.Helper()
+	if err := s.JournalErr(); err != nil {
+		t.Fatalf("JournalErr() = %v", err)
+	}
+	r, err := RecoverFromWAL(path, decodeInt)
+	if err != nil {
+		t.Fatalf("RecoverFromWAL: %v", err)
+	}
+	if got, want := r.Snapshot(), s.Snapshot(); !slices.Equal(got, want) {
+		t.Fatalf("recovered stack holds %v; want %v", got, want)
+	}
+	if r.Maxsize != s.Maxsize {
+		t.Fatalf("recovered Maxsize %d; want %d", r.Maxsize, s.Maxsize)
+	}
+}
+
+func TestWALRecoverRandom(t *testing.T) {
+	for _, every := range []int{0, 8} {
+		path := filepath.Join(t.TempDir(), "stack.wal")
+		s := NewSafeStack([]int{-1, -2})
+		if err := s.EnableWAL(path, encodeInt, every); err != nil {
+			t.Fatalf("EnableWAL: %v", err)
+		}
+
+		rng := rand.New(rand.NewPCG(uint64(every), 260))
+		for i := range 200 {
+			switch rng.IntN(6) {
+			case 0, 1, 2:
+				s.Push(i)
+			case 3:
+				_, _ = s.Pop()
+			case 4:
+				s.NewMax(rng.IntN(20))
+			case 5:
+				_ = s.Rot()
+			}
+			if i%50 == 0 {
+				requireRecovers(t, path, s)
+			}
+		}
+		requireRecovers(t, path, s)
+
+		if err := s.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		entries, err := os.ReadDir(filepath.Dir(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("snapshotEvery %d left %d files behind; want just the log", every, len(entries))
+		}
+	}
+}
+
+func TestWALCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stack.wal")
+	s := NewSafeStack([]int{})
+	if err := s.EnableWAL(path, encodeInt, 4); err != nil {
+		t.Fatalf("EnableWAL: %v", err)
+	}
+	s.NewMax(3)
+	for i := range 40 {
+		s.Push(i)
+	}
+	requireRecovers(t, path, s)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a snapshot is a clear, at most 3 pushes and a max; then fewer than 4 records since
+	if n := bytes.Count(data, []byte("\n")); n > 1+3+1+4 {
+		t.Fatalf("log holds %d records after 40 pushes; want it rewritten down to a snapshot", n)
+	}
+	_ = s.Close()
+}
+
+func TestWALTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stack.wal")
+	s := NewSafeStack([]int{})
+	if err := s.EnableWAL(path, encodeInt, 0); err != nil {
+		t.Fatalf("EnableWAL: %v", err)
+	}
+	s.PushMany([]int{1, 2, 3})
+	s.Push(400)
+	_ = s.Close()
+
+	// a crash halfway through writing the last record
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path, data[:len(data)-3], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := RecoverFromWAL(path, decodeInt)
+	if err != nil {
+		t.Fatalf("RecoverFromWAL of a torn log: %v", err)
+	}
+	if got := r.Snapshot(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("recovered stack holds %v; want [1 2 3] without the torn record", got)
+	}
+}
@@ -0,0 +1,105 @@
+package safestack
+
+import "sync/atomic"
+
+// SafeWorkDeque - a lock-free work-stealing deque (Chase and Lev): one owner goroutine pushes and pops at the top,
+// any number of other goroutines Steal() from the bottom. owner operations touch no shared lock, so they stay cheap
+// however many thieves there are.
+// Push() and Pop() must only ever be called from the owner goroutine; Steal() and Len() are safe from anywhere.
+type SafeWorkDeque[T any] struct {
+	top    atomic.Int64 // the next index to steal
+	bottom atomic.Int64 // the next index to push
+	array  atomic.Pointer[wdArray[T]]
+}
+
+// wdArray - the circular storage of a SafeWorkDeque; its length is always a power of two
+type wdArray[T any] struct {
+	slots []atomic.Pointer[T]
+}
+
+func (a *wdArray[T]) get(i int64) *T {
+	return a.slots[i&int64(len(a.slots)-1)].Load()
+}
+
+func (a *wdArray[T]) put(i int64, v *T) {
+	a.slots[i&int64(len(a.slots)-1)].Store(v)
+}
+
+// grow - return a copy of the array twice the size, holding the items from top to bottom
+func (a *wdArray[T]) grow(top, bottom int64) *wdArray[T] {
+	g := &wdArray[T]{slots: make([]atomic.Pointer[T], 2*len(a.slots))}
+	for i := top; i < bottom; i++ {
+		g.put(i, a.get(i))
+	}
+	return g
+}
+
+// NewSafeWorkDeque - the factory function; return an empty *SafeWorkDeque[T]
+func NewSafeWorkDeque[T any]() *SafeWorkDeque[T] {
+	d := &SafeWorkDeque[T]{}
+	d.array.Store(&wdArray[T]{slots: make([]atomic.Pointer[T], 32)})
+	return d
+}
+
+// Push - add an item to the top; owner only.
+func (d *SafeWorkDeque[T]) Push(item T) {
+	b := d.bottom.Load()
+	t := d.top.Load()
+	a := d.array.Load()
+	if b-t >= int64(len(a.slots))-1 {
+		a = a.grow(t, b)
+		d.array.Store(a)
+	}
+	a.put(b, &item)
+	d.bottom.Store(b + 1)
+}
+
+// Pop - take the item from the top; false if the deque is empty or a thief got the last item first. owner only.
+func (d *SafeWorkDeque[T]) Pop() (T, bool) {
+	var zero T
+	b := d.bottom.Load() - 1
+	a := d.array.Load()
+	d.bottom.Store(b)
+	t := d.top.Load()
+
+	if t > b {
+		d.bottom.Store(b + 1)
+		return zero, false
+	}
+
+	x := a.get(b)
+	if t < b {
+		// no thief can reach this slot: let go of the item
+		a.put(b, nil)
+		return *x, true
+	}
+
+	// the last item: race the thieves for it
+	won := d.top.CompareAndSwap(t, t+1)
+	d.bottom.Store(b + 1)
+	if !won {
+		return zero, false
+	}
+	return *x, true
+}
+
+// Steal - take the item from the bottom; false if the deque is empty or another goroutine took it first.
+func (d *SafeWorkDeque[T]) Steal() (T, bool) {
+	var zero T
+	t := d.top.Load()
+	b := d.bottom.Load()
+	if t >= b {
+		return zero, false
+	}
+
+	x := d.array.Load().get(t)
+	if !d.top.CompareAndSwap(t, t+1) {
+		return zero, false
+	}
+	return *x, true
+}
+
+// Len - return the # of items in the deque; only a snapshot while others are working on it.
+func (d *SafeWorkDeque[T]) Len() int {
+	return int(max(d.bottom.Load()-d.top.Load(), 0))
+}
@@ -0,0 +1,139 @@
+package safestack
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWorkDequeOwner(t *testing.T) {
+	d := NewSafeWorkDeque[int]()
+	if _, ok := d.Pop(); ok {
+		t.Fatal("Pop() of an empty deque succeeded")
+	}
+	if _, ok := d.Steal(); ok {
+		t.Fatal("Steal() from an empty deque succeeded")
+	}
+	for i := range 100 {
+		d.Push(i)
+	}
+	if d.Len() != 100 {
+		t.Fatalf("Len() = %d; want 100", d.Len())
+	}
+	if i, ok := d.Steal(); !ok || i != 0 {
+		t.Fatalf("Steal() = %d, %v; want 0 from the bottom", i, ok)
+	}
+	for want := 99; want > 0; want-- {
+		if i, ok := d.Pop(); !ok || i != want {
+			t.Fatalf("Pop() = %d, %v; want %d from the top", i, ok, want)
+		}
+	}
+	if _, ok := d.Pop(); ok || d.Len() != 0 {
+		t.Fatalf("Pop() of an emptied deque succeeded, or Len() = %d", d.Len())
+	}
+}
+
+// stealAll - run thieves against d until done is set and d is empty; return what each one stole
+func stealAll(d *SafeWorkDeque[int], thieves int, done *atomic.Bool) func() [][]int {
+	var wg sync.WaitGroup
+	stolen := make([][]int, thieves)
+	for th := range thieves {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if i, ok := d.Steal(); ok {
+					stolen[th] = append(stolen[th], i)
+				} else if done.Load() && d.Len() == 0 {
+					return
+				}
+			}
+		}()
+	}
+	return func() [][]int {
+		wg.Wait()
+		return stolen
+	}
+}
+
+// requireEachOnce - fail unless got holds each of 0..n-1 exactly once
+func requireEachOnce(t *testing.T, got []int, n int) {
+	t.Helper()
+	slices.Sort(got)
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("item %d is %d: an item was lost or taken twice (%d taken of %d)", i, v, len(got), n)
+		}
+	}
+	if len(got) != n {
+		t.Fatalf("%d items taken; want %d", len(got), n)
+	}
+}
+
+func TestWorkDequeStealRace(t *testing.T) {
+	const n, thieves = 20000, 4
+	d := NewSafeWorkDeque[int]()
+	var done atomic.Bool
+	wait := stealAll(d, thieves, &done)
+
+	var mine []int
+	for i := range n {
+		d.Push(i)
+		if i%3 == 0 {
+			if v, ok := d.Pop(); ok {
+				mine = append(mine, v)
+			}
+		}
+	}
+	for {
+		v, ok := d.Pop()
+		if !ok {
+			if d.Len() == 0 {
+				break
+			}
+			continue
+		}
+		mine = append(mine, v)
+	}
+	done.Store(true)
+
+	requireEachOnce(t, slices.Concat(append(wait(), mine)...), n)
+}
+
+func TestWorkDequeGrowUnderSteal(t *testing.T) {
+	const n, thieves = 5000, 4
+	d := NewSafeWorkDeque[int]()
+	var done atomic.Bool
+	wait := stealAll(d, thieves, &done)
+
+	// pushes alone outrun the thieves, so the array grows many times while they are reading it
+	for i := range n {
+		d.Push(i)
+	}
+	done.Store(true)
+
+	requireEachOnce(t, slices.Concat(wait()...), n)
+}
+
+func TestWorkDequeLastItemRace(t *testing.T) {
+	d := NewSafeWorkDeque[int]()
+	for round := range 2000 {
+		d.Push(round)
+		var stole, popped bool
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, stole = d.Steal()
+		}()
+		_, popped = d.Pop()
+		wg.Wait()
+		if stole == popped {
+			t.Fatalf("round %d: Steal() got it %v and Pop() got it %v; want exactly one", round, stole, popped)
+		}
+		if d.Len() != 0 {
+			t.Fatalf("round %d: Len() = %d after the last item went; want 0", round, d.Len())
+		}
+	}
+}